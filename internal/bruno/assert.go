@@ -0,0 +1,493 @@
+package bruno
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Assertion outcomes, as Bruno reports them.
+const (
+	AssertPass = "pass"
+	AssertFail = "fail"
+)
+
+// AssertionResult is the outcome of one assert block entry such as
+// "res.status: eq 200".
+type AssertionResult struct {
+	LHSExpr    string `json:"lhsExpr"`
+	RHSExpr    string `json:"rhsExpr"`
+	Operator   string `json:"operator"`
+	RHSOperand string `json:"rhsOperand,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	Actual     any    `json:"actual,omitempty"`
+}
+
+func (a AssertionResult) Passed() bool { return a.Status == AssertPass }
+
+var assertOperators = map[string]bool{
+	"eq": true, "neq": true, "gt": true, "gte": true, "lt": true, "lte": true,
+	"in": true, "notIn": true, "contains": true, "notContains": true, "length": true,
+	"matches": true, "notMatches": true, "startsWith": true, "endsWith": true, "between": true,
+	"isEmpty": true, "isNull": true, "isUndefined": true, "isDefined": true, "isTruthy": true,
+	"isFalsy": true, "isJson": true, "isNumber": true, "isString": true, "isBoolean": true, "isArray": true,
+}
+
+// unaryOperators take no operand.
+var unaryOperators = map[string]bool{
+	"isEmpty": true, "isNull": true, "isUndefined": true, "isDefined": true, "isTruthy": true,
+	"isFalsy": true, "isJson": true, "isNumber": true, "isString": true, "isBoolean": true, "isArray": true,
+}
+
+// EvaluateAssertions checks the enabled entries of an assert block against a
+// response. Operands may use {{variables}} from scope, which may be nil.
+func EvaluateAssertions(assertions []Pair, res *RunResult, scope *Scope) []AssertionResult {
+	out := []AssertionResult{}
+	var body any
+	bodyParsed := false
+
+	for _, a := range assertions {
+		if a.Disabled {
+			continue
+		}
+		r := AssertionResult{LHSExpr: a.Key, RHSExpr: a.Value}
+		r.Operator, r.RHSOperand = parseAssertion(a.Value)
+		if scope != nil {
+			r.RHSOperand = scope.Interpolate(r.RHSOperand)
+		}
+
+		if !bodyParsed && strings.HasPrefix(strings.TrimSpace(a.Key), "res.body") {
+			body, bodyParsed = responseBody(res), true
+		}
+		actual, defined, err := assertionValue(strings.TrimSpace(a.Key), res, body)
+		if err != nil {
+			r.Status, r.Error = AssertFail, err.Error()
+			out = append(out, r)
+			continue
+		}
+		r.Actual = actual
+
+		if err := checkAssertion(r.Operator, r.RHSOperand, actual, defined); err != nil {
+			r.Status, r.Error = AssertFail, err.Error()
+		} else {
+			r.Status = AssertPass
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// parseAssertion splits "op operand". A value without a known operator is an
+// implicit eq, as in Bruno.
+func parseAssertion(v string) (op, operand string) {
+	v = strings.TrimSpace(v)
+	word, rest, _ := strings.Cut(v, " ")
+	if assertOperators[word] {
+		return word, strings.TrimSpace(rest)
+	}
+	return "eq", v
+}
+
+// responseBody decodes a JSON body, falling back to the raw text.
+func responseBody(res *RunResult) any {
+	var v any
+	dec := json.NewDecoder(strings.NewReader(res.Body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		return normalizeJSON(v)
+	}
+	return res.Body
+}
+
+// normalizeJSON turns json.Number into float64.
+func normalizeJSON(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		f, _ := t.Float64()
+		return f
+	case map[string]any:
+		for k, e := range t {
+			t[k] = normalizeJSON(e)
+		}
+	case []any:
+		for i, e := range t {
+			t[i] = normalizeJSON(e)
+		}
+	}
+	return v
+}
+
+// assertionValue evaluates the left-hand side: res.status, res.statusText,
+// res.responseTime, res.headers.<name> or res.body with an optional
+// .key / [index] path.
+func assertionValue(expr string, res *RunResult, body any) (value any, defined bool, err error) {
+	switch expr {
+	case "res.status":
+		return float64(res.Status), true, nil
+	case "res.statusText":
+		return res.StatusText, true, nil
+	case "res.responseTime":
+		return float64(res.DurationMs), true, nil
+	case "res.headers":
+		m := map[string]any{}
+		for k, v := range res.Headers {
+			m[strings.ToLower(k)] = strings.Join(v, ", ")
+		}
+		return m, true, nil
+	}
+	if name, ok := strings.CutPrefix(expr, "res.headers."); ok {
+		vals, ok := res.Headers[http.CanonicalHeaderKey(name)]
+		if !ok {
+			return nil, false, nil
+		}
+		return strings.Join(vals, ", "), true, nil
+	}
+	if path, ok := strings.CutPrefix(expr, "res.body"); ok && (path == "" || path[0] == '.' || path[0] == '[') {
+		v, defined, ok := lookupPath(body, path)
+		if !ok {
+			return nil, false, fmt.Errorf("unsupported assertion expression %q", expr)
+		}
+		return v, defined, nil
+	}
+	return nil, false, fmt.Errorf("unsupported assertion expression %q", expr)
+}
+
+var pathTokenRe = regexp.MustCompile(`\.([^.\[\]]+)|\[(\d+)\]|\[["']([^"']*)["']\]`)
+
+// lookupPath walks .key, [index] and ["key"] steps into a decoded JSON value.
+// valid is false when path is not made up of such steps alone.
+func lookupPath(v any, path string) (value any, defined, valid bool) {
+	end := 0
+	for _, loc := range pathTokenRe.FindAllStringSubmatchIndex(path, -1) {
+		if loc[0] != end {
+			return nil, false, false
+		}
+		end = loc[1]
+	}
+	if end != len(path) {
+		return nil, false, false
+	}
+
+	for _, m := range pathTokenRe.FindAllStringSubmatch(path, -1) {
+		switch {
+		case m[2] != "":
+			arr, ok := v.([]any)
+			i, _ := strconv.Atoi(m[2])
+			if !ok || i >= len(arr) {
+				return nil, false, true
+			}
+			v = arr[i]
+		default:
+			key := m[1] + m[3]
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return nil, false, true
+			}
+			if v, ok = obj[key]; !ok {
+				return nil, false, true
+			}
+		}
+	}
+	return v, true, true
+}
+
+// operandValue interprets an operand the way Bruno evaluates it: quoted
+// strings stay strings, true/false/null/numbers are typed, anything else is
+// text. quoted reports whether the operand was an explicit string.
+func operandValue(s string) (v any, quoted bool) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], true
+	}
+	switch s {
+	case "true":
+		return true, false
+	case "false":
+		return false, false
+	case "null":
+		return nil, false
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, false
+	}
+	if strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+		var j any
+		if json.Unmarshal([]byte(s), &j) == nil {
+			return j, false
+		}
+	}
+	return s, false
+}
+
+// looseEqual compares like Bruno's eq: deep equality, except that a string
+// actual value is compared with the operand's text unless it was quoted.
+func looseEqual(actual any, operand string) bool {
+	want, quoted := operandValue(operand)
+	if s, ok := actual.(string); ok && !quoted {
+		return s == strings.TrimSpace(operand)
+	}
+	return reflect.DeepEqual(actual, want)
+}
+
+func checkAssertion(op, operand string, actual any, defined bool) error {
+	if unaryOperators[op] && operand != "" {
+		return fmt.Errorf("operator %s takes no operand, got %q", op, operand)
+	}
+	// undefined is falsy in Bruno, so isFalsy holds for it too.
+	if !defined && op != "isUndefined" && op != "isDefined" && op != "isFalsy" {
+		return fmt.Errorf("expected value to be defined")
+	}
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("expected %s "+format, append([]any{describe(actual)}, args...)...)
+	}
+
+	switch op {
+	case "eq":
+		if !looseEqual(actual, operand) {
+			return fail("to equal %s", operand)
+		}
+	case "neq":
+		if looseEqual(actual, operand) {
+			return fail("to not equal %s", operand)
+		}
+
+	case "gt", "gte", "lt", "lte":
+		a, ok1 := toNumber(actual)
+		b, ok2 := toNumber(operand)
+		if !ok1 || !ok2 {
+			return fail("and %s to be numbers", operand)
+		}
+		ok := map[string]bool{"gt": a > b, "gte": a >= b, "lt": a < b, "lte": a <= b}[op]
+		if !ok {
+			return fail("to be %s %s", map[string]string{"gt": ">", "gte": ">=", "lt": "<", "lte": "<="}[op], operand)
+		}
+
+	case "between":
+		lo, hi, _ := strings.Cut(operand, ",")
+		a, ok1 := toNumber(actual)
+		l, ok2 := toNumber(lo)
+		h, ok3 := toNumber(hi)
+		if !ok1 || !ok2 || !ok3 {
+			return fail("and %s to be numbers", operand)
+		}
+		if a < l || a > h {
+			return fail("to be between %s", operand)
+		}
+
+	case "in", "notIn":
+		found := false
+		for _, item := range splitOperandList(operand) {
+			if looseEqual(actual, item) {
+				found = true
+				break
+			}
+		}
+		if op == "in" && !found {
+			return fail("to be one of %s", operand)
+		}
+		if op == "notIn" && found {
+			return fail("to not be one of %s", operand)
+		}
+
+	case "contains", "notContains":
+		found := false
+		switch t := actual.(type) {
+		case string:
+			want, _ := operandValue(operand)
+			found = strings.Contains(t, fmt.Sprint(want))
+		case []any:
+			for _, e := range t {
+				if looseEqual(e, operand) {
+					found = true
+					break
+				}
+			}
+		case map[string]any:
+			want, _ := operandValue(operand)
+			_, found = t[fmt.Sprint(want)]
+		default:
+			return fail("to be a string, array or object")
+		}
+		if op == "contains" && !found {
+			return fail("to contain %s", operand)
+		}
+		if op == "notContains" && found {
+			return fail("to not contain %s", operand)
+		}
+
+	case "length":
+		n, ok := toNumber(operand)
+		if !ok {
+			return fmt.Errorf("length operand %q is not a number", operand)
+		}
+		l, ok := lengthOf(actual)
+		if !ok {
+			return fail("to have a length")
+		}
+		if float64(l) != n {
+			return fail("to have length %s, got %d", operand, l)
+		}
+
+	case "matches", "notMatches":
+		pattern, _ := operandValue(operand)
+		re, err := regexp.Compile(trimRegexSlashes(fmt.Sprint(pattern)))
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", operand, err)
+		}
+		m := re.MatchString(toText(actual))
+		if op == "matches" && !m {
+			return fail("to match %s", operand)
+		}
+		if op == "notMatches" && m {
+			return fail("to not match %s", operand)
+		}
+
+	case "startsWith", "endsWith":
+		s, ok := actual.(string)
+		want, _ := operandValue(operand)
+		if !ok {
+			return fail("to be a string")
+		}
+		if op == "startsWith" && !strings.HasPrefix(s, fmt.Sprint(want)) {
+			return fail("to start with %s", operand)
+		}
+		if op == "endsWith" && !strings.HasSuffix(s, fmt.Sprint(want)) {
+			return fail("to end with %s", operand)
+		}
+
+	case "isEmpty":
+		if l, ok := lengthOf(actual); !ok || l != 0 {
+			return fail("to be empty")
+		}
+	case "isNull":
+		if actual != nil {
+			return fail("to be null")
+		}
+	case "isUndefined":
+		if defined {
+			return fail("to be undefined")
+		}
+	case "isDefined":
+		if !defined {
+			return fmt.Errorf("expected value to be defined")
+		}
+	case "isTruthy":
+		if !truthy(actual) {
+			return fail("to be truthy")
+		}
+	case "isFalsy":
+		if truthy(actual) {
+			return fail("to be falsy")
+		}
+	case "isJson":
+		switch t := actual.(type) {
+		case map[string]any, []any:
+		case string:
+			var v any
+			if json.Unmarshal([]byte(t), &v) != nil {
+				return fail("to be JSON")
+			}
+		default:
+			return fail("to be JSON")
+		}
+	case "isNumber":
+		if _, ok := actual.(float64); !ok {
+			return fail("to be a number")
+		}
+	case "isString":
+		if _, ok := actual.(string); !ok {
+			return fail("to be a string")
+		}
+	case "isBoolean":
+		if _, ok := actual.(bool); !ok {
+			return fail("to be a boolean")
+		}
+	case "isArray":
+		if _, ok := actual.([]any); !ok {
+			return fail("to be an array")
+		}
+
+	default:
+		return fmt.Errorf("unknown operator %q", op)
+	}
+	return nil
+}
+
+// splitOperandList splits "a, b, c" or "[a, b, c]" into items.
+func splitOperandList(s string) []string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		s = s[1 : len(s)-1]
+	}
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		out = append(out, strings.TrimSpace(item))
+	}
+	return out
+}
+
+func trimRegexSlashes(p string) string {
+	if len(p) >= 2 && p[0] == '/' && strings.LastIndexByte(p, '/') > 0 {
+		return p[1:strings.LastIndexByte(p, '/')]
+	}
+	return p
+}
+
+func toNumber(v any) (float64, bool) {
+	switch t := v.(type) {
+	case float64:
+		return t, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func lengthOf(v any) (int, bool) {
+	switch t := v.(type) {
+	case string:
+		return len([]rune(t)), true
+	case []any:
+		return len(t), true
+	case map[string]any:
+		return len(t), true
+	}
+	return 0, false
+}
+
+// truthy follows JavaScript truthiness.
+func truthy(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	case float64:
+		return t != 0 && !math.IsNaN(t)
+	case string:
+		return t != ""
+	}
+	return true
+}
+
+func toText(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func describe(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return toText(v)
+}
@@ -0,0 +1,126 @@
+package bruno
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEvaluateAssertions_Operators(t *testing.T) {
+	res := &RunResult{
+		Status:     200,
+		StatusText: "OK",
+		Headers:    http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+		Body:       `{"id": 7, "name": "Ada", "tags": ["a", "b"], "empty": [], "none": null, "ok": true, "nested": {"n": 1.5}, "raw": "{\"x\":1}"}`,
+		DurationMs: 12,
+	}
+
+	cases := []struct {
+		key, value string
+		pass       bool
+	}{
+		{"res.status", "eq 200", true},
+		{"res.status", "200", true},
+		{"res.status", "neq 404", true},
+		{"res.status", "eq 201", false},
+		{"res.status", "gt 199", true},
+		{"res.status", "gte 200", true},
+		{"res.status", "lt 200", false},
+		{"res.status", "lte 200", true},
+		{"res.status", "in 200, 201", true},
+		{"res.status", "notIn 200,201", false},
+		{"res.status", "between 100, 299", true},
+		{"res.responseTime", "lt 1000", true},
+		{"res.statusText", "eq OK", true},
+		{"res.headers.content-type", "contains json", true},
+		{"res.headers.content-type", "notContains xml", true},
+		{"res.headers.content-type", "startsWith application/", true},
+		{"res.headers.content-type", "endsWith utf-8", true},
+		{"res.headers.x-missing", "isUndefined", true},
+		{"res.body.id", "isNumber", true},
+		{"res.body.id", "eq '7'", false},
+		{"res.body.name", "eq Ada", true},
+		{"res.body.name", "eq \"Ada\"", true},
+		{"res.body.name", "isString", true},
+		{"res.body.name", "matches ^A.a$", true},
+		{"res.body.name", "notMatches /^b/", true},
+		{"res.body.name", "length 3", true},
+		{"res.body.tags", "isArray", true},
+		{"res.body.tags", "contains a", true},
+		{"res.body.tags", "length 2", true},
+		{"res.body.tags[1]", "eq b", true},
+		{"res.body.empty", "isEmpty", true},
+		{"res.body.tags", "isEmpty", false},
+		{"res.body.none", "isNull", true},
+		{"res.body.missing", "isUndefined", true},
+		{"res.body.missing", "isDefined", false},
+		{"res.body.missing", "eq 1", false},
+		{"res.body.ok", "isBoolean", true},
+		{"res.body.ok", "isTruthy", true},
+		{"res.body.ok", "eq true", true},
+		{"res.body.none", "isFalsy", true},
+		{"res.body.missing", "isFalsy", true},
+		{"res.body.missing", "isTruthy", false},
+		{"res.body.nested", "isJson", true},
+		{"res.body.raw", "isJson", true},
+		{"res.body.name", "isJson", false},
+		{"res.body.nested.n", "eq 1.5", true},
+		{"res.body", "isDefined", true},
+		{"res.body.id", "isNumber extra", false},
+		{"req.url", "isDefined", false},
+		{"res.bodyX", "isDefined", false},
+		{"res.body..id", "isDefined", false},
+		{"res.body.id junk", "isDefined", false},
+		{"res.body[x]", "isDefined", false},
+		{"res.body[\"name\"]", "eq Ada", true},
+	}
+
+	for _, tc := range cases {
+		got := EvaluateAssertions([]Pair{{Key: tc.key, Value: tc.value}}, res, nil)
+		if len(got) != 1 || got[0].Passed() != tc.pass {
+			t.Fatalf("%s: %s: expected pass=%v got %+v", tc.key, tc.value, tc.pass, got)
+		}
+	}
+
+	got := EvaluateAssertions([]Pair{{Key: "res.bodyX", Value: "isDefined"}}, res, nil)
+	if len(got) != 1 || !strings.Contains(got[0].Error, "unsupported assertion expression") {
+		t.Fatalf("expected an unsupported expression error got %+v", got)
+	}
+
+	got = EvaluateAssertions([]Pair{{Key: "res.status", Value: "eq 500", Disabled: true}}, res, nil)
+	if len(got) != 0 {
+		t.Fatalf("expected disabled assertion to be skipped got %+v", got)
+	}
+}
+
+func TestRunRequest_Assertions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 3}`))
+	}))
+	defer srv.Close()
+
+	c, ws := newTestCollection(t)
+	writeFile(t, filepath.Join(ws, "api", "get.bru"), "get {\n  url: "+srv.URL+"\n}\n\nassert {\n  res.status: eq 200\n  res.body.id: eq {{expected}}\n}\n\nvars:pre-request {\n  expected: 4\n}\n")
+
+	res, err := c.RunRequest(context.Background(), ws, "api", "get", RunOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if len(res.Assertions) != 2 || !res.Assertions[0].Passed() || res.Assertions[1].Passed() || res.Passed() {
+		t.Fatalf("unexpected assertions: %+v", res.Assertions)
+	}
+	if res.Assertions[1].RHSOperand != "4" || res.Assertions[1].Error == "" {
+		t.Fatalf("expected interpolated operand and error got %+v", res.Assertions[1])
+	}
+
+	res, err = c.RunRequest(context.Background(), ws, "api", "get", RunOptions{Assertions: []Pair{{Key: "res.body.id", Value: "eq 3"}}})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if len(res.Assertions) != 1 || !res.Passed() {
+		t.Fatalf("expected override assertions to pass got %+v", res.Assertions)
+	}
+}
@@ -0,0 +1,361 @@
+package bruno
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Pos is a location in .bru source. Line and Col are 1-based; Col counts bytes.
+type Pos struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Col    int `json:"col"`
+}
+
+func (p Pos) String() string { return fmt.Sprintf("%d:%d", p.Line, p.Col) }
+
+type SyntaxError struct {
+	Pos Pos
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("bru syntax error at %s: %s", e.Pos, e.Msg)
+}
+
+func (e *SyntaxError) Unwrap() error { return ErrInvalidBru }
+
+type BlockKind int
+
+const (
+	// DictBlock holds "key: value" lines, e.g. meta, headers, assert.
+	DictBlock BlockKind = iota
+	// TextBlock holds free-form indented text, e.g. body:json, script:*, docs.
+	TextBlock
+	// ListBlock holds bracketed items, e.g. vars:secret [ a, b ].
+	ListBlock
+)
+
+func (k BlockKind) String() string {
+	switch k {
+	case DictBlock:
+		return "dict"
+	case TextBlock:
+		return "text"
+	case ListBlock:
+		return "list"
+	default:
+		return fmt.Sprintf("BlockKind(%d)", int(k))
+	}
+}
+
+// Pair is one entry of a dict block. In list blocks only Key is set.
+type Pair struct {
+	Key      string `json:"key"`
+	Value    string `json:"value,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Pos      Pos    `json:"-"`
+}
+
+type Block struct {
+	Name  string
+	Kind  BlockKind
+	Pairs []Pair // DictBlock and ListBlock
+	Text  string // TextBlock, with the two-space indent removed
+	Pos   Pos
+
+	// Source bookkeeping for lossless round trips; see format.go.
+	lead string // bytes between the previous block and this one
+	raw  string // header through closing delimiter, as read
+	orig *Block // content as parsed from raw
+}
+
+// Document is a .bru file as an ordered list of blocks.
+type Document struct {
+	Blocks []*Block
+
+	parsed   bool
+	trailing string // bytes after the last block
+}
+
+// Block returns the first block with the given name, or nil.
+func (d *Document) Block(name string) *Block {
+	for _, b := range d.Blocks {
+		if b.Name == name {
+			return b
+		}
+	}
+	return nil
+}
+
+// Get returns the value of the first enabled pair with the given key.
+func (b *Block) Get(key string) (string, bool) {
+	if b == nil {
+		return "", false
+	}
+	for _, p := range b.Pairs {
+		if p.Key == key && !p.Disabled {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// textBlocks are parsed as free text. Every other "{" block is a dict unless
+// its name has one of the textPrefixes.
+var textBlocks = map[string]bool{
+	"body:json":         true,
+	"body:text":         true,
+	"body:xml":          true,
+	"body:sparql":       true,
+	"body:graphql":      true,
+	"body:graphql:vars": true,
+	"tests":             true,
+	"docs":              true,
+}
+
+var textPrefixes = []string{"script:"}
+
+func blockKindFor(name string, open byte) BlockKind {
+	if open == '[' {
+		return ListBlock
+	}
+	if textBlocks[name] {
+		return TextBlock
+	}
+	for _, p := range textPrefixes {
+		if strings.HasPrefix(name, p) {
+			return TextBlock
+		}
+	}
+	return DictBlock
+}
+
+type line struct {
+	text string // without the line terminator
+	pos  Pos
+	end  int // offset of the line terminator
+}
+
+func splitLines(src []byte) []line {
+	var out []line
+	off, n := 0, 1
+	for off < len(src) {
+		i := bytes.IndexByte(src[off:], '\n')
+		end := len(src)
+		if i >= 0 {
+			end = off + i
+		}
+		text := strings.TrimSuffix(string(src[off:end]), "\r")
+		out = append(out, line{text: text, pos: Pos{Offset: off, Line: n, Col: 1}, end: end})
+		off = end + 1
+		n++
+	}
+	return out
+}
+
+func isNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == ':' || c == '-' || c == '_' || c == '.'
+}
+
+// parseHeader recognises "name {" / "name [" and the empty "name {}" form.
+func parseHeader(s string) (name string, open byte, empty bool, ok bool) {
+	s = strings.TrimRight(s, " \t")
+	i := 0
+	for i < len(s) && isNameByte(s[i]) {
+		i++
+	}
+	if i == 0 {
+		return "", 0, false, false
+	}
+	name = s[:i]
+	rest := strings.TrimLeft(s[i:], " \t")
+	switch rest {
+	case "{", "[":
+		return name, rest[0], false, true
+	case "{}", "[]":
+		return name, rest[0], true, true
+	}
+	return "", 0, false, false
+}
+
+// ParseDocument splits .bru source into blocks and parses the body of each
+// according to its kind.
+func ParseDocument(src []byte) (*Document, error) {
+	lines := splitLines(src)
+	doc := &Document{parsed: true}
+	prevEnd := 0
+
+	for i := 0; i < len(lines); i++ {
+		ln := lines[i]
+		if strings.TrimSpace(ln.text) == "" {
+			continue
+		}
+		name, open, empty, ok := parseHeader(ln.text)
+		if !ok {
+			return nil, &SyntaxError{Pos: ln.pos, Msg: fmt.Sprintf("expected block header, got %q", ln.text)}
+		}
+
+		b := &Block{Name: name, Kind: blockKindFor(name, open), Pos: ln.pos}
+		b.lead = string(src[prevEnd:ln.pos.Offset])
+		if empty {
+			b.raw = string(src[ln.pos.Offset:ln.end])
+			b.orig = b.clone()
+			doc.Blocks = append(doc.Blocks, b)
+			prevEnd = ln.end
+			continue
+		}
+
+		closer := "}"
+		if open == '[' {
+			closer = "]"
+		}
+		j := i + 1
+		for j < len(lines) && strings.TrimRight(lines[j].text, " \t") != closer {
+			j++
+		}
+		if j == len(lines) {
+			return nil, &SyntaxError{Pos: ln.pos, Msg: fmt.Sprintf("unterminated block %q", name)}
+		}
+
+		body := lines[i+1 : j]
+		var err error
+		switch b.Kind {
+		case TextBlock:
+			b.Text = parseText(body)
+		case ListBlock:
+			b.Pairs = parseList(body)
+		default:
+			b.Pairs, err = parseDict(body)
+			if err != nil && blockRank(name) == len(blockOrder) {
+				// Unknown blocks only need to survive a round trip; keep
+				// anything that is not a dict as text.
+				b.Kind, b.Pairs, b.Text, err = TextBlock, nil, parseText(body), nil
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		b.raw = string(src[ln.pos.Offset:lines[j].end])
+		b.orig = b.clone()
+		doc.Blocks = append(doc.Blocks, b)
+		prevEnd = lines[j].end
+		i = j
+	}
+
+	doc.trailing = string(src[prevEnd:])
+	return doc, nil
+}
+
+func parseText(body []line) string {
+	parts := make([]string, len(body))
+	for i, ln := range body {
+		parts[i] = strings.TrimPrefix(ln.text, "  ")
+	}
+	return strings.Join(parts, "\n")
+}
+
+func parseList(body []line) []Pair {
+	var out []Pair
+	for _, ln := range body {
+		item := strings.TrimSpace(ln.text)
+		item = strings.TrimSpace(strings.TrimSuffix(item, ","))
+		if item == "" {
+			continue
+		}
+		p := Pair{Pos: indentPos(ln)}
+		if strings.HasPrefix(item, "~") {
+			p.Disabled = true
+			item = item[1:]
+		}
+		p.Key = item
+		out = append(out, p)
+	}
+	return out
+}
+
+const multilineQuote = "'''"
+
+func parseDict(body []line) ([]Pair, error) {
+	var out []Pair
+	for i := 0; i < len(body); i++ {
+		ln := body[i]
+		s := strings.TrimSpace(ln.text)
+		if s == "" {
+			continue
+		}
+
+		p := Pair{Pos: indentPos(ln)}
+		if strings.HasPrefix(s, "~") {
+			p.Disabled = true
+			s = s[1:]
+		}
+
+		key, rest, err := splitKey(s)
+		if err != nil {
+			return nil, &SyntaxError{Pos: p.Pos, Msg: err.Error()}
+		}
+		p.Key = key
+		p.Value = strings.TrimSpace(rest)
+
+		if p.Value == multilineQuote {
+			j := i + 1
+			for j < len(body) && strings.TrimSpace(body[j].text) != multilineQuote {
+				j++
+			}
+			if j == len(body) {
+				return nil, &SyntaxError{Pos: p.Pos, Msg: fmt.Sprintf("unterminated multiline value for %q", key)}
+			}
+			parts := make([]string, 0, j-i-1)
+			for _, v := range body[i+1 : j] {
+				parts = append(parts, strings.TrimPrefix(v.text, "    "))
+			}
+			p.Value = strings.Join(parts, "\n")
+			i = j
+		}
+
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// splitKey splits "key: value" at the first colon. Keys may be double-quoted
+// to allow colons and spaces.
+func splitKey(s string) (key, rest string, err error) {
+	if strings.HasPrefix(s, `"`) {
+		end := 1
+		for end < len(s) && s[end] != '"' {
+			if s[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(s) {
+			return "", "", fmt.Errorf("unterminated quoted key")
+		}
+		key = strings.ReplaceAll(s[1:end], `\"`, `"`)
+		after := strings.TrimLeft(s[end+1:], " \t")
+		if !strings.HasPrefix(after, ":") {
+			return "", "", fmt.Errorf("expected ':' after key %q", key)
+		}
+		return key, after[1:], nil
+	}
+
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return "", "", fmt.Errorf("expected \"key: value\", got %q", s)
+	}
+	key = strings.TrimSpace(s[:i])
+	if key == "" {
+		return "", "", fmt.Errorf("empty key")
+	}
+	return key, s[i+1:], nil
+}
+
+func indentPos(ln line) Pos {
+	n := len(ln.text) - len(strings.TrimLeft(ln.text, " \t"))
+	return Pos{Offset: ln.pos.Offset + n, Line: ln.pos.Line, Col: n + 1}
+}
@@ -0,0 +1,183 @@
+package bruno
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minCLIVersion is the oldest bru CLI the server drives; earlier releases
+// predate the reporter flags.
+var minCLIVersion = [3]int{1, 0, 0}
+
+const cliProbeTimeout = 10 * time.Second
+
+// CLIInfo describes the bru CLI found on PATH. Commands and RunFlags are read
+// from its help output; they are empty when the help could not be parsed, in
+// which case Supports assumes a flag is available.
+type CLIInfo struct {
+	Available bool     `json:"available"`
+	Path      string   `json:"path,omitempty"`
+	Version   string   `json:"version,omitempty"`
+	Supported bool     `json:"supported"`
+	Commands  []string `json:"commands,omitempty"`
+	RunFlags  []string `json:"runFlags,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// Supports reports whether `bru run` accepts flag (e.g. "--reporter-json").
+func (i *CLIInfo) Supports(flag string) bool {
+	if len(i.RunFlags) == 0 {
+		return i.Available
+	}
+	for _, f := range i.RunFlags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// CLIInfo probes the bru CLI once and returns what it found. Later calls
+// return the cached result.
+func (c *Client) CLIInfo() *CLIInfo {
+	c.cliOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cliProbeTimeout)
+		defer cancel()
+		c.cli.Store(c.probeCLI(ctx))
+	})
+	return c.cli.Load()
+}
+
+// CLIInfoWithin is CLIInfo but gives up after d and returns nil. The probe
+// carries on in the background; later calls return its result.
+func (c *Client) CLIInfoWithin(d time.Duration) *CLIInfo {
+	if info := c.cli.Load(); info != nil {
+		return info
+	}
+	done := make(chan *CLIInfo, 1)
+	go func() { done <- c.CLIInfo() }()
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case info := <-done:
+		return info
+	case <-t.C:
+		return nil
+	}
+}
+
+func (c *Client) probeCLI(ctx context.Context) *CLIInfo {
+	info := &CLIInfo{Path: c.brunoPath}
+	if !c.hasCli() {
+		info.Error = ErrBruNotFound.Error()
+		return info
+	}
+
+	out, _, err := c.run(ctx, "--version")
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	info.Available = true
+	v, ok := parseCLIVersion(out)
+	if !ok {
+		info.Error = fmt.Sprintf("unrecognised version output: %q", strings.TrimSpace(out))
+		return info
+	}
+	info.Version = fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+	info.Supported = !versionLess(v, minCLIVersion)
+
+	if out, _, err := c.run(ctx, "--help"); err == nil {
+		info.Commands = parseHelpCommands(out)
+	}
+	if out, _, err := c.run(ctx, "run", "--help"); err == nil {
+		info.RunFlags = parseHelpFlags(out)
+	}
+	return info
+}
+
+// requireCLI returns an error unless bru is installed, is a supported
+// version and its run command accepts every flag in flags.
+func (c *Client) requireCLI(flags ...string) error {
+	info := c.CLIInfo()
+	if !info.Available {
+		if info.Error != "" && info.Error != ErrBruNotFound.Error() {
+			return fmt.Errorf("%w: %s", ErrBruNotFound, info.Error)
+		}
+		return ErrBruNotFound
+	}
+	if !info.Supported {
+		return fmt.Errorf("%w: found %q, need %d.%d.%d or later", ErrUnsupportedCLI, info.Version, minCLIVersion[0], minCLIVersion[1], minCLIVersion[2])
+	}
+	for _, f := range flags {
+		if !info.Supports(f) {
+			return fmt.Errorf("%w: bru %s does not support %s", ErrUnsupportedCLI, info.Version, f)
+		}
+	}
+	return nil
+}
+
+var cliVersionRe = regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)`)
+
+func parseCLIVersion(out string) ([3]int, bool) {
+	var v [3]int
+	m := cliVersionRe.FindStringSubmatch(out)
+	if m == nil {
+		return v, false
+	}
+	for i := range v {
+		v[i], _ = strconv.Atoi(m[i+1])
+	}
+	return v, true
+}
+
+func versionLess(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// parseHelpCommands reads the "Commands:" section of yargs help, whose lines
+// look like "  bru run [paths...]  Run one or more requests".
+func parseHelpCommands(help string) []string {
+	var cmds []string
+	in := false
+	for _, line := range strings.Split(help, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasSuffix(trimmed, ":") && !strings.HasPrefix(line, " "):
+			in = trimmed == "Commands:"
+		case in && trimmed != "":
+			f := strings.Fields(trimmed)
+			if len(f) > 1 && f[0] == "bru" && !strings.HasPrefix(f[1], "[") && !strings.HasPrefix(f[1], "<") {
+				cmds = append(cmds, f[1])
+			}
+		}
+	}
+	sort.Strings(cmds)
+	return cmds
+}
+
+var helpFlagRe = regexp.MustCompile(`(?:^|[\s,])(--[a-zA-Z][\w-]*)`)
+
+func parseHelpFlags(help string) []string {
+	seen := map[string]bool{}
+	var flags []string
+	for _, m := range helpFlagRe.FindAllStringSubmatch(help, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			flags = append(flags, m[1])
+		}
+	}
+	sort.Strings(flags)
+	return flags
+}
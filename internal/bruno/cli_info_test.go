@@ -0,0 +1,69 @@
+package bruno
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCLIInfo(t *testing.T) {
+	installFakeBru(t)
+	c := NewClient()
+
+	info := c.CLIInfo()
+	if !info.Available || !info.Supported || info.Version != "1.38.1" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	if !reflect.DeepEqual(info.Commands, []string{"import", "run"}) {
+		t.Fatalf("unexpected commands: %v", info.Commands)
+	}
+	if !info.Supports("--reporter-json") || info.Supports("--reporter-junit") {
+		t.Fatalf("unexpected flags: %v", info.RunFlags)
+	}
+
+	// The probe runs once.
+	t.Setenv("FAKE_BRU_VERSION", "2.0.0")
+	if c.CLIInfo().Version != "1.38.1" {
+		t.Fatalf("expected cached info")
+	}
+}
+
+func TestCLIInfoMissing(t *testing.T) {
+	info := (&Client{}).CLIInfo()
+	if info.Available || info.Error == "" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+}
+
+func TestRunWithCLIUnsupported(t *testing.T) {
+	installFakeBru(t)
+	_, ws := newTestCollection(t)
+
+	t.Setenv("FAKE_BRU_VERSION", "bru 0.9.2")
+	if _, err := NewClient().RunWithCLI(context.Background(), ws, "api", CLIRunOptions{}); !errors.Is(err, ErrUnsupportedCLI) {
+		t.Fatalf("expected ErrUnsupportedCLI for old version, got %v", err)
+	}
+
+	t.Setenv("FAKE_BRU_VERSION", "1.40.0")
+	t.Setenv("FAKE_BRU_RUN_HELP", "Options:\n  --env  Environment\n  --output  Path")
+	if _, err := NewClient().RunWithCLI(context.Background(), ws, "api", CLIRunOptions{}); !errors.Is(err, ErrUnsupportedCLI) {
+		t.Fatalf("expected ErrUnsupportedCLI for missing --reporter-json, got %v", err)
+	}
+}
+
+func TestParseCLIVersion(t *testing.T) {
+	for in, want := range map[string][3]int{
+		"1.38.1\n":         {1, 38, 1},
+		"bru v2.3.0":       {2, 3, 0},
+		"Bru CLI 1.2.10\n": {1, 2, 10},
+	} {
+		got, ok := parseCLIVersion(in)
+		if !ok || got != want {
+			t.Fatalf("parseCLIVersion(%q) = %v, %v", in, got, ok)
+		}
+	}
+	if _, ok := parseCLIVersion("unknown"); ok {
+		t.Fatalf("expected no version")
+	}
+}
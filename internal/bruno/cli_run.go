@@ -0,0 +1,139 @@
+package bruno
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// CLIRunOptions controls a run through the bru CLI.
+type CLIRunOptions struct {
+	Environment string `json:"environment,omitempty"`
+	Folder      string `json:"folder,omitempty"`
+	Bail        bool   `json:"bail,omitempty"`
+}
+
+// CLIRunResult is the outcome of `bru run`, read from its JSON report. A
+// non-zero ExitCode with a report means requests failed; Stderr is kept for
+// diagnostics.
+type CLIRunResult struct {
+	Collection  string            `json:"collection"`
+	Folder      string            `json:"folder,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Summary     JSONReportSummary `json:"summary"`
+	Iterations  JSONReport        `json:"iterations"`
+	ExitCode    int               `json:"exitCode"`
+	Stderr      string            `json:"stderr,omitempty"`
+	DurationMs  int64             `json:"durationMs"`
+}
+
+// Passed reports whether bru succeeded and no request failed or errored.
+func (r *CLIRunResult) Passed() bool {
+	return r.ExitCode == 0 && r.Summary.FailedRequests == 0 && r.Summary.ErrorRequests == 0
+}
+
+// RunWithCLI runs a collection, or one of its folders, with `bru run` in the
+// collection root. Unlike RunCollection, scripts and tests are executed by
+// Bruno itself.
+func (c *Client) RunWithCLI(ctx context.Context, workspaceDir, collection string, opts CLIRunOptions) (*CLIRunResult, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"run"}
+	if opts.Folder != "" {
+		_, dir, err := existingFolder(workspaceDir, collection, opts.Folder)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, relSlash(colRoot, dir))
+	}
+	args = append(args, "-r")
+	if opts.Environment != "" {
+		path, err := environmentPath(workspaceDir, collection, opts.Environment)
+		if err != nil {
+			return nil, err
+		}
+		if !fileExists(path) {
+			return nil, fmt.Errorf("%w: %q", ErrEnvironmentNotFound, opts.Environment)
+		}
+		args = append(args, "--env", strings.TrimSuffix(opts.Environment, ".bru"))
+	}
+	if opts.Bail {
+		args = append(args, "--bail")
+	}
+
+	if err := c.requireCLI(cliRunFlags(opts)...); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "bru-report-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("create report file: %w", err)
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+	args = append(args, "--reporter-json", tmp.Name())
+
+	out := &CLIRunResult{Collection: collection, Folder: opts.Folder, Environment: opts.Environment}
+	start := time.Now()
+	_, stderr, runErr := c.runIn(ctx, colRoot, args...)
+	out.DurationMs = time.Since(start).Milliseconds()
+	out.Stderr = stderr
+
+	report, err := readJSONReport(tmp.Name())
+	if runErr != nil {
+		// bru exits non-zero when requests fail; that is a result, not an
+		// error, as long as it wrote its report.
+		var ce *CommandError
+		var ee *exec.ExitError
+		if err != nil || !errors.As(runErr, &ce) || !errors.As(ce.Err, &ee) {
+			if s := strings.TrimSpace(stderr); s != "" {
+				return nil, fmt.Errorf("%w: %s", runErr, s)
+			}
+			return nil, runErr
+		}
+		out.ExitCode = ee.ExitCode()
+	} else if err != nil {
+		return nil, err
+	}
+
+	out.Iterations = report
+	for _, it := range report {
+		out.Summary.add(it.Summary)
+	}
+	return out, nil
+}
+
+// cliRunFlags lists the bru run flags opts needs.
+func cliRunFlags(opts CLIRunOptions) []string {
+	flags := []string{"--reporter-json"}
+	if opts.Environment != "" {
+		flags = append(flags, "--env")
+	}
+	if opts.Bail {
+		flags = append(flags, "--bail")
+	}
+	return flags
+}
+
+func readJSONReport(path string) (JSONReport, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read bru report: %w", err)
+	}
+	if len(strings.TrimSpace(string(b))) == 0 {
+		return nil, fmt.Errorf("%w: bru wrote no report", ErrCommandFailed)
+	}
+	var report JSONReport
+	if err := json.Unmarshal(b, &report); err != nil {
+		return nil, fmt.Errorf("%w: parse bru report: %v", ErrCommandFailed, err)
+	}
+	return report, nil
+}
@@ -0,0 +1,114 @@
+package bruno
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const fakeBruScript = `#!/bin/sh
+case "$1" in
+  --version) echo "${FAKE_BRU_VERSION:-1.38.1}"; exit 0 ;;
+  --help) printf 'bru <command> [options]\n\nCommands:\n  bru run [paths...]  Run one or more requests/folders\n  bru import <type>   Import a collection\n\nOptions:\n  --version  Show version number\n'; exit 0 ;;
+esac
+if [ "$1" = "run" ] && [ "$2" = "--help" ]; then
+  printf '%s\n' "${FAKE_BRU_RUN_HELP:-Options:
+  -r               Indicates a recursive run
+  --env            Environment variables               [string]
+  --bail           Stop execution after a failure     [boolean]
+  --reporter-json  Path to write file results to       [string]}"
+  exit 0
+fi
+echo "$PWD $*" > "$FAKE_BRU_LOG"
+out=""
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--reporter-json" ]; then out="$2"; fi
+  shift
+done
+if [ -n "$FAKE_BRU_REPORT" ] && [ -n "$out" ]; then
+  printf '%s' "$FAKE_BRU_REPORT" > "$out"
+fi
+echo "bru failed" >&2
+exit ${FAKE_BRU_EXIT:-0}
+`
+
+// installFakeBru puts a bru script on PATH that logs its working directory
+// and arguments and writes FAKE_BRU_REPORT as the JSON report.
+func installFakeBru(t *testing.T) (logPath string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bru is a shell script")
+	}
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "bru"), []byte(fakeBruScript), 0o755); err != nil {
+		t.Fatalf("write fake bru: %v", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	logPath = filepath.Join(t.TempDir(), "bru.log")
+	t.Setenv("FAKE_BRU_LOG", logPath)
+	return logPath
+}
+
+const fakeReport = `[{"iterationIndex":0,"summary":{"totalRequests":2,"passedRequests":1,"failedRequests":1,"totalAssertions":2,"passedAssertions":1,"failedAssertions":1},
+"results":[{"test":{"filename":"users/list.bru"},"request":{"method":"GET","url":"http://x/users","headers":{}},"response":{"status":200,"statusText":"OK","headers":{},"data":{"ok":true},"responseTime":5},"error":null,"status":"pass","assertionResults":[{"lhsExpr":"res.status","rhsExpr":"eq 200","rhsOperand":"200","operator":"eq","status":"pass"}],"testResults":[],"runtime":0.005,"suitename":"users/list","iterationIndex":0},
+{"test":{"filename":"users/get.bru"},"request":{"method":"GET","url":"http://x/users/1","headers":{}},"response":{"status":404,"statusText":"Not Found","headers":{},"data":"","responseTime":3},"error":null,"status":"fail","assertionResults":[{"lhsExpr":"res.status","rhsExpr":"eq 200","rhsOperand":"200","operator":"eq","status":"fail","error":"expected 404 to equal 200"}],"testResults":[],"runtime":0.003,"suitename":"users/get","iterationIndex":0}]}]`
+
+func TestRunWithCLI(t *testing.T) {
+	logPath := installFakeBru(t)
+	t.Setenv("FAKE_BRU_REPORT", fakeReport)
+	t.Setenv("FAKE_BRU_EXIT", "1")
+
+	_, ws := newTestCollection(t)
+	writeFile(t, filepath.Join(ws, "api", "users", "list.bru"), "get {\n  url: http://x/users\n}\n")
+	writeFile(t, filepath.Join(ws, "api", "environments", "dev.bru"), "vars {\n  host: x\n}\n")
+
+	c := NewClient()
+	res, err := c.RunWithCLI(context.Background(), ws, "api", CLIRunOptions{Environment: "dev", Folder: "users", Bail: true})
+	if err != nil {
+		t.Fatalf("RunWithCLI: %v", err)
+	}
+	if res.ExitCode != 1 || res.Passed() || res.Summary.FailedRequests != 1 || len(res.Iterations[0].Results) != 2 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if a := res.Iterations[0].Results[1].AssertionResults[0]; a.Status != "fail" || a.Error == "" {
+		t.Fatalf("unexpected assertion: %+v", a)
+	}
+
+	b, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	log := string(b)
+	colRoot, _ := filepath.EvalSymlinks(filepath.Join(ws, "api"))
+	if !strings.HasPrefix(log, colRoot+" ") && !strings.HasPrefix(log, filepath.Join(ws, "api")+" ") {
+		t.Fatalf("expected bru to run in the collection root, got %q", log)
+	}
+	if !strings.Contains(log, "run users -r --env dev --bail --reporter-json ") {
+		t.Fatalf("unexpected args: %q", log)
+	}
+}
+
+func TestRunWithCLIErrors(t *testing.T) {
+	installFakeBru(t)
+	t.Setenv("FAKE_BRU_EXIT", "2")
+	_, ws := newTestCollection(t)
+	c := NewClient()
+
+	_, err := c.RunWithCLI(context.Background(), ws, "api", CLIRunOptions{})
+	if !errors.Is(err, ErrCommandFailed) || !strings.Contains(err.Error(), "bru failed") {
+		t.Fatalf("expected command failure with stderr, got %v", err)
+	}
+	if _, err := c.RunWithCLI(context.Background(), ws, "api", CLIRunOptions{Environment: "prod"}); !errors.Is(err, ErrEnvironmentNotFound) {
+		t.Fatalf("expected ErrEnvironmentNotFound, got %v", err)
+	}
+	if _, err := c.RunWithCLI(context.Background(), ws, "api", CLIRunOptions{Folder: "missing"}); !errors.Is(err, ErrFolderNotFound) {
+		t.Fatalf("expected ErrFolderNotFound, got %v", err)
+	}
+	if _, err := (&Client{}).RunWithCLI(context.Background(), ws, "api", CLIRunOptions{}); !errors.Is(err, ErrBruNotFound) {
+		t.Fatalf("expected ErrBruNotFound, got %v", err)
+	}
+}
@@ -10,10 +10,20 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type Client struct {
 	brunoPath string
+
+	cliOnce sync.Once
+	cli     atomic.Pointer[CLIInfo]
+
+	scanMu sync.Mutex
+	scans  map[string]map[string]bruScan // collection root -> .bru path -> scan
+
+	secrets *Redactor
 }
 
 func NewClient() *Client {
@@ -21,6 +31,10 @@ func NewClient() *Client {
 	return &Client{brunoPath: path}
 }
 
+// MaskResolvedSecrets makes every value the client resolves from process.env
+// or a .env file a secret of r, whichever placeholder asked for it.
+func (c *Client) MaskResolvedSecrets(r *Redactor) { c.secrets = r }
+
 func (c *Client) hasCli() bool { return c.brunoPath != "" }
 
 type CommandError struct {
@@ -36,11 +50,18 @@ func (e *CommandError) Error() string {
 func (e *CommandError) Unwrap() error { return ErrCommandFailed }
 
 func (c *Client) run(ctx context.Context, args ...string) (stdout, stderr string, err error) {
+	return c.runIn(ctx, "", args...)
+}
+
+// runIn is run with the working directory set to dir; bru resolves the
+// collection from its working directory.
+func (c *Client) runIn(ctx context.Context, dir string, args ...string) (stdout, stderr string, err error) {
 	if c.brunoPath == "" {
 		return "", "", ErrBruNotFound
 	}
 
 	cmd := exec.CommandContext(ctx, c.brunoPath, args...)
+	cmd.Dir = dir
 	outB, err := cmd.Output()
 
 	if err != nil {
@@ -87,7 +108,7 @@ func (c *Client) ListCollections(workspaceDir string) ([]string, error) {
 			continue
 		}
 		name := e.Name()
-		if name == ".git" || name == "node_modules" {
+		if IgnoredDir(name) {
 			continue
 		}
 		dir := filepath.Join(workspaceDir, name)
@@ -101,38 +122,9 @@ func (c *Client) ListCollections(workspaceDir string) ([]string, error) {
 }
 
 func (c *Client) ListRequests(workspaceDir, collection string) ([]string, error) {
-	workspaceDir = filepath.Clean(workspaceDir)
-	collection = strings.TrimSpace(collection)
-	if collection == "" {
-		return nil, fmt.Errorf("%w: collection is required", ErrInvalidRequestPath)
-	}
-
-	var colRoot string
-	if fileExists(filepath.Join(workspaceDir, "bruno.json")) && collection == filepath.Base(workspaceDir) {
-		colRoot = workspaceDir
-	} else {
-		j, err := safeJoin(workspaceDir, collection)
-		if err != nil {
-			return nil, err
-		}
-		colRoot = j
-	}
-
-	info, err := os.Stat(colRoot)
+	colRoot, err := collectionRoot(workspaceDir, collection)
 	if err != nil {
-		return nil, fmt.Errorf("collection path stat failed: %w", err)
-	}
-	if !info.IsDir() {
-		return nil, fmt.Errorf("collection path is not a directory: %q", colRoot)
-	}
-	if !fileExists(filepath.Join(colRoot, "bruno.json")) {
-		return nil, fmt.Errorf("not a Bruno collection (missing bruno.json): %q", colRoot)
-	}
-
-	skipDirs := map[string]bool{
-		".git":         true,
-		"node_modules": true,
-		"environments": true, // env files are .bru too; skip them for “requests”
+		return nil, err
 	}
 
 	var reqs []string
@@ -142,7 +134,8 @@ func (c *Client) ListRequests(workspaceDir, collection string) ([]string, error)
 		}
 
 		if d.IsDir() {
-			if skipDirs[d.Name()] {
+			// env files are .bru too; skip them for “requests”
+			if skipCollectionDir(d.Name()) {
 				return fs.SkipDir
 			}
 			return nil
@@ -171,6 +164,122 @@ func (c *Client) ListRequests(workspaceDir, collection string) ([]string, error)
 	return reqs, nil
 }
 
+// GetRequest parses the request file at relRequestPath inside a collection.
+// The .bru extension is optional.
+func (c *Client) GetRequest(workspaceDir, collection, relRequestPath string) (*Request, error) {
+	_, fullPath, err := resolveRequest(workspaceDir, collection, relRequestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := LoadRequestFile(fullPath)
+	if err != nil {
+		return nil, err
+	}
+	return f.Request, nil
+}
+
+// ReadCollectionFile returns the source of a file inside a collection:
+// bruno.json or any .bru file (requests, folder.bru, collection.bru and
+// environments). The .bru extension is optional.
+func (c *Client) ReadCollectionFile(workspaceDir, collection, relPath string) ([]byte, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	var fullPath string
+	if filepath.ToSlash(filepath.Clean(relPath)) == "bruno.json" {
+		fullPath = filepath.Join(colRoot, "bruno.json")
+	} else if fullPath, err = requestPathIn(colRoot, relPath); err != nil {
+		return nil, err
+	}
+	parts := strings.Split(relSlash(colRoot, fullPath), "/")
+	for _, dir := range parts[:len(parts)-1] {
+		if IgnoredDir(dir) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRequestPath, relPath)
+		}
+	}
+
+	src, err := os.ReadFile(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %q", ErrRequestNotFound, relPath)
+		}
+		return nil, fmt.Errorf("read %q: %w", relPath, err)
+	}
+	return src, nil
+}
+
+// CollectionOf finds the collection holding a file, given its slash-separated
+// path relative to the workspace, the way ListCollections finds collections:
+// the nearest of a top-level directory with bruno.json and the workspace
+// itself. rel is the path inside the collection. A deleted bruno.json still
+// belongs to the collection it defined.
+func (c *Client) CollectionOf(workspaceDir, relPath string) (collection, rel string, ok bool) {
+	first, rest, nested := strings.Cut(relPath, "/")
+	if nested && (rest == "bruno.json" || fileExists(filepath.Join(workspaceDir, first, "bruno.json"))) {
+		return first, rest, true
+	}
+	if relPath == "bruno.json" || fileExists(filepath.Join(workspaceDir, "bruno.json")) {
+		return filepath.Base(filepath.Clean(workspaceDir)), relPath, true
+	}
+	return "", "", false
+}
+
+// collectionRoot resolves a collection name to its directory. The workspace
+// itself counts as a collection when it holds bruno.json and the name matches
+// its base name.
+func collectionRoot(workspaceDir, collection string) (string, error) {
+	workspaceDir = filepath.Clean(workspaceDir)
+	collection = strings.TrimSpace(collection)
+	if collection == "" {
+		return "", fmt.Errorf("%w: collection is required", ErrInvalidRequestPath)
+	}
+
+	var colRoot string
+	if fileExists(filepath.Join(workspaceDir, "bruno.json")) && collection == filepath.Base(workspaceDir) {
+		colRoot = workspaceDir
+	} else {
+		j, err := safeJoin(workspaceDir, collection)
+		if err != nil {
+			return "", err
+		}
+		colRoot = j
+	}
+
+	info, err := os.Stat(colRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %q", ErrCollectionMissing, collection)
+		}
+		return "", fmt.Errorf("collection path stat failed: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("collection path is not a directory: %q", colRoot)
+	}
+	if !fileExists(filepath.Join(colRoot, "bruno.json")) {
+		return "", fmt.Errorf("%w (missing bruno.json): %q", ErrNotACollection, colRoot)
+	}
+	return colRoot, nil
+}
+
+// requestPathIn resolves a request path inside a collection, adding the .bru
+// extension when it is missing.
+func requestPathIn(colRoot, relRequestPath string) (string, error) {
+	relRequestPath = strings.TrimSpace(relRequestPath)
+	if relRequestPath == "" {
+		return "", fmt.Errorf("%w: empty path", ErrInvalidRequestPath)
+	}
+	if filepath.IsAbs(relRequestPath) {
+		return "", fmt.Errorf("%w: absolute path: %q", ErrInvalidRequestPath, relRequestPath)
+	}
+	if !strings.HasSuffix(strings.ToLower(relRequestPath), ".bru") {
+		relRequestPath += ".bru"
+	}
+	return safeJoin(colRoot, relRequestPath)
+}
+
 func fileExists(path string) bool {
 	st, err := os.Stat(path)
 	return err == nil && !st.IsDir()
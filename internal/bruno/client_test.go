@@ -2,6 +2,9 @@ package bruno
 
 import (
 	"context"
+	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -30,3 +33,31 @@ func TestRunCapturesStderrOnFailure(t *testing.T) {
 		t.Fatalf("expected stderr to be captured, got empty")
 	}
 }
+
+func TestReadCollectionFile(t *testing.T) {
+	c, ws := newTestCollection(t)
+	writeFile(t, filepath.Join(ws, "api", "users", "list.bru"), "get {\n  url: http://x/users\n}\n")
+	writeFile(t, filepath.Join(ws, "api", "environments", "dev.bru"), "vars {\n  host: x\n}\n")
+
+	for rel, want := range map[string]string{
+		"users/list":           "url: http://x/users",
+		"users/list.bru":       "url: http://x/users",
+		"environments/dev.bru": "host: x",
+		"bruno.json":           `"type"`,
+	} {
+		src, err := c.ReadCollectionFile(ws, "api", rel)
+		if err != nil {
+			t.Fatalf("ReadCollectionFile(%q): %v", rel, err)
+		}
+		if !strings.Contains(string(src), want) {
+			t.Fatalf("ReadCollectionFile(%q) = %q, want %q in it", rel, src, want)
+		}
+	}
+
+	if _, err := c.ReadCollectionFile(ws, "api", "missing.bru"); !errors.Is(err, ErrRequestNotFound) {
+		t.Fatalf("expected ErrRequestNotFound, got %v", err)
+	}
+	if _, err := c.ReadCollectionFile(ws, "api", "../api/users/list.bru"); err == nil {
+		t.Fatalf("expected traversal to be rejected")
+	}
+}
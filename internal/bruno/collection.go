@@ -0,0 +1,225 @@
+package bruno
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	collectionFile = "collection.bru"
+	configFile     = "bruno.json"
+)
+
+// Collection is a collection's collection.bru settings together with its
+// bruno.json. Config is kept as generic JSON so that fields this package does
+// not model survive updates.
+type Collection struct {
+	Name     string         `json:"name"`
+	Path     string         `json:"path"`
+	Settings Settings       `json:"settings"`
+	Config   map[string]any `json:"config"`
+}
+
+// BrunoConfig is the part of bruno.json that is validated on update.
+type BrunoConfig struct {
+	Version            string              `json:"version"`
+	Name               string              `json:"name"`
+	Type               string              `json:"type"`
+	Ignore             []string            `json:"ignore,omitempty"`
+	Scripts            *ScriptsConfig      `json:"scripts,omitempty"`
+	Presets            *PresetsConfig      `json:"presets,omitempty"`
+	Proxy              *ProxyConfig        `json:"proxy,omitempty"`
+	ClientCertificates *ClientCertificates `json:"clientCertificates,omitempty"`
+}
+
+type ScriptsConfig struct {
+	ModuleWhitelist  []string `json:"moduleWhitelist,omitempty"`
+	FilesystemAccess *struct {
+		Allow bool `json:"allow"`
+	} `json:"filesystemAccess,omitempty"`
+}
+
+type PresetsConfig struct {
+	RequestType string `json:"requestType,omitempty"`
+	RequestURL  string `json:"requestUrl,omitempty"`
+}
+
+type ProxyConfig struct {
+	// Enabled is true, false or "global".
+	Enabled  any    `json:"enabled,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+	Port     *int   `json:"port,omitempty"`
+	Auth     *struct {
+		Enabled  bool   `json:"enabled"`
+		Username string `json:"username,omitempty"`
+		Password string `json:"password,omitempty"`
+	} `json:"auth,omitempty"`
+	BypassProxy string `json:"bypassProxy,omitempty"`
+}
+
+type ClientCertificates struct {
+	Enabled bool         `json:"enabled"`
+	Certs   []ClientCert `json:"certs,omitempty"`
+}
+
+type ClientCert struct {
+	Domain       string `json:"domain"`
+	Type         string `json:"type,omitempty"`
+	CertFilePath string `json:"certFilePath,omitempty"`
+	KeyFilePath  string `json:"keyFilePath,omitempty"`
+	PfxFilePath  string `json:"pfxFilePath,omitempty"`
+	Passphrase   string `json:"passphrase,omitempty"`
+}
+
+func (cfg *BrunoConfig) validate() error {
+	if strings.TrimSpace(cfg.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidConfig)
+	}
+	if cfg.Type != "collection" {
+		return fmt.Errorf("%w: type must be \"collection\", got %q", ErrInvalidConfig, cfg.Type)
+	}
+	if p := cfg.Proxy; p != nil {
+		switch p.Enabled {
+		case nil, true, false, "global":
+		default:
+			return fmt.Errorf("%w: proxy.enabled must be true, false or \"global\"", ErrInvalidConfig)
+		}
+		switch p.Protocol {
+		case "", "http", "https", "socks4", "socks5":
+		default:
+			return fmt.Errorf("%w: unsupported proxy protocol: %q", ErrInvalidConfig, p.Protocol)
+		}
+	}
+	if cc := cfg.ClientCertificates; cc != nil {
+		for i, c := range cc.Certs {
+			if strings.TrimSpace(c.Domain) == "" {
+				return fmt.Errorf("%w: clientCertificates.certs[%d].domain is required", ErrInvalidConfig, i)
+			}
+		}
+	}
+	return nil
+}
+
+// CollectionPatch is a partial change to a collection. Settings follows
+// SettingsPatch semantics, except that Name renames the collection in
+// bruno.json and Seq is not allowed. Config is a JSON merge patch (RFC 7396)
+// applied to bruno.json: null deletes a key, objects merge, anything else
+// replaces.
+type CollectionPatch struct {
+	Settings *SettingsPatch `json:"settings,omitempty"`
+	Config   map[string]any `json:"config,omitempty"`
+}
+
+func (c *Client) GetCollection(workspaceDir, collection string) (*Collection, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+	f, err := LoadSettingsFile(filepath.Join(colRoot, collectionFile))
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := readConfig(colRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &Collection{Name: collection, Path: colRoot, Settings: *f.Settings, Config: cfg}, nil
+}
+
+// UpdateCollection applies a patch to collection.bru and bruno.json. Both
+// changes are validated before either file is written.
+func (c *Client) UpdateCollection(workspaceDir, collection string, patch CollectionPatch) (*Collection, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+	f, err := LoadSettingsFile(filepath.Join(colRoot, collectionFile))
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := readConfig(colRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.Config != nil {
+		cfg = mergePatch(cfg, patch.Config).(map[string]any)
+	}
+	if sp := patch.Settings; sp != nil {
+		if sp.Seq != nil {
+			return nil, fmt.Errorf("%w: seq does not apply to collections", ErrInvalidConfig)
+		}
+		if sp.Name != nil {
+			cfg["name"] = strings.TrimSpace(*sp.Name)
+		}
+		rest := *sp
+		rest.Name = nil
+		if err := rest.Apply(f.Settings); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	var typed BrunoConfig
+	if err := json.Unmarshal(b, &typed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if err := typed.validate(); err != nil {
+		return nil, err
+	}
+
+	if patch.Config != nil || (patch.Settings != nil && patch.Settings.Name != nil) {
+		if err := os.WriteFile(filepath.Join(colRoot, configFile), b, 0o644); err != nil {
+			return nil, fmt.Errorf("write bruno.json: %w", err)
+		}
+	}
+	if patch.Settings != nil {
+		if err := f.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Collection{Name: collection, Path: colRoot, Settings: *f.Settings, Config: cfg}, nil
+}
+
+func readConfig(colRoot string) (map[string]any, error) {
+	b, err := os.ReadFile(filepath.Join(colRoot, configFile))
+	if err != nil {
+		return nil, fmt.Errorf("read bruno.json: %w", err)
+	}
+	var cfg map[string]any
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: bruno.json: %v", ErrInvalidConfig, err)
+	}
+	if cfg == nil {
+		cfg = map[string]any{}
+	}
+	return cfg, nil
+}
+
+// mergePatch applies an RFC 7396 JSON merge patch.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
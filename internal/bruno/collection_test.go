@@ -0,0 +1,76 @@
+package bruno
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUpdateCollection_SettingsAndConfig(t *testing.T) {
+	c, ws := newTestCollection(t)
+
+	docs := "Public API"
+	got, err := c.UpdateCollection(ws, "api", CollectionPatch{
+		Settings: &SettingsPatch{
+			SetHeaders: []Pair{{Key: "X-Client", Value: "mcp"}},
+			Auth:       &Auth{Mode: "bearer", Bearer: &BearerAuth{Token: "{{token}}"}},
+			Docs:       &docs,
+		},
+		Config: map[string]any{
+			"scripts": map[string]any{"moduleWhitelist": []any{"crypto"}},
+			"presets": map[string]any{"requestType": "http", "requestUrl": "https://api.example.com"},
+			"proxy":   map[string]any{"enabled": "global"},
+			"ignore":  nil,
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if got.Settings.Auth.Bearer == nil || len(got.Settings.Headers) != 1 {
+		t.Fatalf("unexpected settings: %+v", got.Settings)
+	}
+	if _, ok := got.Config["ignore"]; ok {
+		t.Fatalf("expected ignore to be removed by merge patch")
+	}
+
+	again, err := c.GetCollection(ws, "api")
+	if err != nil {
+		t.Fatalf("GetCollection: %v", err)
+	}
+	if again.Settings.Docs != docs || again.Config["name"] != "api" {
+		t.Fatalf("unexpected collection after reload: %+v", again)
+	}
+	scripts, _ := again.Config["scripts"].(map[string]any)
+	if wl, _ := scripts["moduleWhitelist"].([]any); len(wl) != 1 {
+		t.Fatalf("expected moduleWhitelist to persist, got %+v", again.Config)
+	}
+
+	src, _ := os.ReadFile(filepath.Join(ws, "api", "collection.bru"))
+	if !strings.Contains(string(src), "auth {\n  mode: bearer\n}") {
+		t.Fatalf("expected auth block in collection.bru:\n%s", src)
+	}
+}
+
+func TestUpdateCollection_RejectsInvalidConfig(t *testing.T) {
+	c, ws := newTestCollection(t)
+	before, _ := os.ReadFile(filepath.Join(ws, "api", "bruno.json"))
+
+	cases := []CollectionPatch{
+		{Config: map[string]any{"type": "workspace"}},
+		{Config: map[string]any{"proxy": map[string]any{"protocol": "ftp"}}},
+		{Config: map[string]any{"ignore": "node_modules"}},
+		{Settings: &SettingsPatch{Seq: new(int)}},
+	}
+	for i, p := range cases {
+		if _, err := c.UpdateCollection(ws, "api", p); !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("case %d: expected ErrInvalidConfig got %v", i, err)
+		}
+	}
+
+	after, _ := os.ReadFile(filepath.Join(ws, "api", "bruno.json"))
+	if string(before) != string(after) {
+		t.Fatalf("bruno.json changed after rejected patches")
+	}
+}
@@ -61,6 +61,12 @@ func (c *Client) CreateCollection(workspaceDir, name string, opts CreateCollecti
 type CreateRequestOptions struct {
 	Overwrite bool
 	Seq       int
+
+	Headers    []Pair
+	Query      []Pair // merged with the URL's query string, which is rewritten to match
+	PathParams []Pair
+	Body       Body
+	Auth       Auth
 }
 
 func (c *Client) CreateRequest(workspaceDir, collection, relRequestPath, method, url string, opts CreateRequestOptions) (string, error) {
@@ -80,19 +86,9 @@ func (c *Client) CreateRequest(workspaceDir, collection, relRequestPath, method,
 		relRequestPath += ".bru"
 	}
 
-	var colRoot string
-	if fileExists(filepath.Join(workspaceDir, "bruno.json")) && collection == filepath.Base(workspaceDir) {
-		colRoot = workspaceDir
-	} else {
-		j, err := safeJoin(workspaceDir, collection)
-		if err != nil {
-			return "", err
-		}
-		colRoot = j
-	}
-
-	if !fileExists(filepath.Join(colRoot, "bruno.json")) {
-		return "", fmt.Errorf("not a Bruno collection (missing bruno.json): %q", colRoot)
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return "", err
 	}
 
 	fullPath, err := safeJoin(colRoot, relRequestPath)
@@ -116,19 +112,36 @@ func (c *Client) CreateRequest(workspaceDir, collection, relRequestPath, method,
 		return "", fmt.Errorf("%w: empty url", ErrInvalidRequestPath)
 	}
 
+	if err := validateBody(&opts.Body); err != nil {
+		return "", err
+	}
+	if err := validateAuth(&opts.Auth); err != nil {
+		return "", err
+	}
+
 	seq := opts.Seq
 	if seq <= 0 {
 		seq = nextSeq(filepath.Dir(fullPath))
 	}
 
-	// Minimal .bru format using tags from docs :contentReference[oaicite:3]{index=3}
 	displayName := strings.TrimSuffix(filepath.Base(relRequestPath), ".bru")
-	content := fmt.Sprintf(
-		"meta {\n  name: %s\n  type: http\n  seq: %d\n}\n\n%s {\n  url: %s\n}\n",
-		displayName, seq, method, url,
-	)
+	r := &Request{
+		Meta:       Meta{Name: displayName, Type: "http", Seq: seq},
+		Method:     method,
+		URL:        url,
+		Headers:    opts.Headers,
+		PathParams: opts.PathParams,
+		Body:       opts.Body,
+		Auth:       opts.Auth,
+	}
+	// The URL's own query string seeds the params; explicit ones win.
+	r.Query = patchPairs(queryFromURL(url), opts.Query, nil, false)
+	if len(opts.Query) > 0 {
+		syncURLQuery(r)
+	}
+	content := FormatRequest(r)
 
-	if err := os.WriteFile(fullPath, []byte(content), 0o644); err != nil {
+	if err := os.WriteFile(fullPath, content, 0o644); err != nil {
 		return "", fmt.Errorf("write request: %w", err)
 	}
 
@@ -145,6 +158,102 @@ func isAllowedMethod(m string) bool {
 	}
 }
 
+func validateBody(b *Body) error {
+	switch b.Mode {
+	case "":
+		if b.JSON != "" || b.Text != "" || b.XML != "" || b.SPARQL != "" ||
+			len(b.FormURLEncoded) > 0 || len(b.MultipartForm) > 0 || b.GraphQL != nil {
+			return fmt.Errorf("%w: body content given without a body mode", ErrInvalidRequestPath)
+		}
+	case BodyNone, BodyJSON, BodyText, BodyXML, BodySPARQL, BodyFormURLEncoded, BodyMultipartForm:
+	case BodyGraphQL:
+		if b.GraphQL == nil {
+			b.GraphQL = &GraphQL{}
+		}
+	default:
+		return fmt.Errorf("%w: unsupported body mode: %q", ErrInvalidRequestPath, b.Mode)
+	}
+	return nil
+}
+
+// validateAuth checks the mode and makes sure the settings block for a typed
+// mode exists, so that e.g. mode "bearer" always writes auth:bearer.
+func validateAuth(a *Auth) error {
+	switch a.Mode {
+	case "", "none", "inherit", "awsv4", "oauth2", "ntlm", "wsse":
+	case "basic":
+		if a.Basic == nil {
+			a.Basic = &BasicAuth{}
+		}
+	case "digest":
+		if a.Digest == nil {
+			a.Digest = &BasicAuth{}
+		}
+	case "bearer":
+		if a.Bearer == nil {
+			a.Bearer = &BearerAuth{}
+		}
+	case "apikey":
+		if a.APIKey == nil {
+			a.APIKey = &APIKeyAuth{Placement: "header"}
+		}
+		if a.APIKey.Placement == "" {
+			a.APIKey.Placement = "header"
+		}
+		if a.APIKey.Placement != "header" && a.APIKey.Placement != "queryparams" {
+			return fmt.Errorf("%w: unsupported apikey placement: %q", ErrInvalidRequestPath, a.APIKey.Placement)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported auth mode: %q", ErrInvalidRequestPath, a.Mode)
+	}
+	return nil
+}
+
+// syncURLQuery rewrites the query string of r.URL from the enabled query
+// params. Bruno sends the URL as written, so the two must agree.
+func syncURLQuery(r *Request) {
+	base, frag := r.URL, ""
+	if i := strings.IndexByte(base, '#'); i >= 0 {
+		base, frag = base[:i], base[i:]
+	}
+	if i := strings.IndexByte(base, '?'); i >= 0 {
+		base = base[:i]
+	}
+
+	var parts []string
+	for _, p := range r.Query {
+		if p.Disabled {
+			continue
+		}
+		parts = append(parts, p.Key+"="+p.Value)
+	}
+	if len(parts) > 0 {
+		base += "?" + strings.Join(parts, "&")
+	}
+	r.URL = base + frag
+}
+
+// queryFromURL parses the query string of a Bruno URL without decoding it, so
+// that {{var}} placeholders survive.
+func queryFromURL(url string) []Pair {
+	if i := strings.IndexByte(url, '#'); i >= 0 {
+		url = url[:i]
+	}
+	i := strings.IndexByte(url, '?')
+	if i < 0 {
+		return nil
+	}
+	var out []Pair
+	for _, kv := range strings.Split(url[i+1:], "&") {
+		if kv == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(kv, "=")
+		out = append(out, Pair{Key: k, Value: v})
+	}
+	return out
+}
+
 func nextSeq(dir string) int {
 	ents, err := os.ReadDir(dir)
 	if err != nil {
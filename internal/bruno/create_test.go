@@ -0,0 +1,90 @@
+package bruno
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateRequest_RichOptions(t *testing.T) {
+	c, ws := newTestCollection(t)
+
+	_, err := c.CreateRequest(ws, "api", "users/search", "POST", "{{baseUrl}}/users/:org/search", CreateRequestOptions{
+		Headers:    []Pair{{Key: "Accept", Value: "application/json"}},
+		Query:      []Pair{{Key: "limit", Value: "10"}, {Key: "debug", Value: "1", Disabled: true}},
+		PathParams: []Pair{{Key: "org", Value: "acme"}},
+		Body: Body{
+			Mode:    BodyGraphQL,
+			GraphQL: &GraphQL{Query: "query { users { id } }", Variables: "{\n  \"n\": 1\n}"},
+		},
+		Auth: Auth{Mode: "apikey", APIKey: &APIKeyAuth{Key: "X-Key", Value: "{{key}}"}},
+	})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+
+	f, err := LoadRequestFile(filepath.Join(ws, "api", "users", "search.bru"))
+	if err != nil {
+		t.Fatalf("expected created file to parse, got %v", err)
+	}
+	r := f.Request
+	if r.URL != "{{baseUrl}}/users/:org/search?limit=10" {
+		t.Fatalf("expected enabled query in url, got %q", r.URL)
+	}
+	if len(r.Query) != 2 || !r.Query[1].Disabled {
+		t.Fatalf("unexpected query: %+v", r.Query)
+	}
+	if len(r.PathParams) != 1 || len(r.Headers) != 1 {
+		t.Fatalf("unexpected params/headers: %+v %+v", r.PathParams, r.Headers)
+	}
+	if r.Body.Mode != BodyGraphQL || r.Body.GraphQL == nil || r.Body.GraphQL.Variables != "{\n  \"n\": 1\n}" {
+		t.Fatalf("unexpected body: %+v", r.Body)
+	}
+	if r.Auth.Mode != "apikey" || r.Auth.APIKey == nil || r.Auth.APIKey.Placement != "header" {
+		t.Fatalf("unexpected auth: %+v", r.Auth)
+	}
+}
+
+func TestCreateRequest_MergesURLQuery(t *testing.T) {
+	c, ws := newTestCollection(t)
+
+	if _, err := c.CreateRequest(ws, "api", "plain", "get", "https://example.com/users?a=1&b={{b}}", CreateRequestOptions{}); err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	r, err := c.GetRequest(ws, "api", "plain")
+	if err != nil {
+		t.Fatalf("GetRequest: %v", err)
+	}
+	if r.URL != "https://example.com/users?a=1&b={{b}}" || len(r.Query) != 2 || r.Query[1].Key != "b" || r.Query[1].Value != "{{b}}" {
+		t.Fatalf("expected the URL query as params, got %q %+v", r.URL, r.Query)
+	}
+
+	if _, err := c.CreateRequest(ws, "api", "merged", "get", "https://example.com/users?a=1&b=2", CreateRequestOptions{
+		Query: []Pair{{Key: "b", Value: "3"}, {Key: "c", Value: "4"}},
+	}); err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if r, err = c.GetRequest(ws, "api", "merged"); err != nil {
+		t.Fatalf("GetRequest: %v", err)
+	}
+	if r.URL != "https://example.com/users?a=1&b=3&c=4" || len(r.Query) != 3 {
+		t.Fatalf("expected the URL query merged with the params, got %q %+v", r.URL, r.Query)
+	}
+}
+
+func TestCreateRequest_RejectsBadModes(t *testing.T) {
+	c, ws := newTestCollection(t)
+
+	cases := []CreateRequestOptions{
+		{Body: Body{Mode: "yaml"}},
+		{Body: Body{JSON: "{}"}},
+		{Auth: Auth{Mode: "kerberos"}},
+		{Auth: Auth{Mode: "apikey", APIKey: &APIKeyAuth{Placement: "cookie"}}},
+	}
+	for i, opts := range cases {
+		_, err := c.CreateRequest(ws, "api", "bad", "get", "https://example.com", opts)
+		if !errors.Is(err, ErrInvalidRequestPath) {
+			t.Fatalf("case %d: expected ErrInvalidRequestPath got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package bruno
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const environmentsDir = "environments"
+
+// Environment is a collection environment file (environments/<name>.bru).
+// Secret variables are listed in vars:secret by name only; Bruno keeps
+// their values outside the file.
+type Environment struct {
+	Name      string   `json:"name"`
+	Variables []EnvVar `json:"variables"`
+}
+
+type EnvVar struct {
+	Name     string `json:"name"`
+	Value    string `json:"value,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Secret   bool   `json:"secret,omitempty"`
+}
+
+// Environment interprets the document as an environment file.
+func (d *Document) Environment() *Environment {
+	e := &Environment{Variables: []EnvVar{}}
+	if b := d.Block("vars"); b != nil {
+		for _, p := range b.Pairs {
+			e.Variables = append(e.Variables, EnvVar{Name: p.Key, Value: p.Value, Disabled: p.Disabled})
+		}
+	}
+	if b := d.Block("vars:secret"); b != nil {
+		for _, p := range b.Pairs {
+			e.Variables = append(e.Variables, EnvVar{Name: p.Key, Disabled: p.Disabled, Secret: true})
+		}
+	}
+	return e
+}
+
+// SetEnvironment writes the variables into the document.
+func (d *Document) SetEnvironment(e *Environment) {
+	var vars, secrets []Pair
+	for _, v := range e.Variables {
+		if v.Secret {
+			secrets = append(secrets, Pair{Key: v.Name, Disabled: v.Disabled})
+		} else {
+			vars = append(vars, Pair{Key: v.Name, Value: v.Value, Disabled: v.Disabled})
+		}
+	}
+	if b := d.Block("vars"); len(vars) == 0 {
+		// Bruno always writes a vars block, even an empty one.
+		if b == nil {
+			d.insert(&Block{Name: "vars", Kind: DictBlock})
+		} else {
+			b.Pairs = nil
+		}
+	} else {
+		d.SetPairs("vars", vars)
+	}
+	d.SetList("vars:secret", secrets)
+}
+
+func validateEnvVars(vars []EnvVar) error {
+	seen := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		name := strings.TrimSpace(v.Name)
+		if name == "" || strings.ContainsAny(name, ":{}[],\n") || strings.HasPrefix(name, "~") {
+			return fmt.Errorf("%w: invalid variable name %q", ErrInvalidEnvironment, v.Name)
+		}
+		if seen[name] {
+			return fmt.Errorf("%w: duplicate variable %q", ErrInvalidEnvironment, name)
+		}
+		seen[name] = true
+		if v.Secret && v.Value != "" {
+			return fmt.Errorf("%w: secret %q cannot have a value in the environment file", ErrInvalidEnvironment, name)
+		}
+	}
+	return nil
+}
+
+// EnvironmentPatch is a partial change to an environment. Set replaces
+// variables by name or appends them; Remove drops them by name.
+type EnvironmentPatch struct {
+	Rename *string  `json:"rename,omitempty"`
+	Set    []EnvVar `json:"set,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+}
+
+func (c *Client) ListEnvironments(workspaceDir, collection string) ([]string, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+	ents, err := os.ReadDir(filepath.Join(colRoot, environmentsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("read environments dir failed: %w", err)
+	}
+
+	names := []string{}
+	for _, e := range ents {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".bru" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".bru"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (c *Client) GetEnvironment(workspaceDir, collection, name string) (*Environment, error) {
+	path, err := environmentPath(workspaceDir, collection, name)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := loadEnvironment(path, name)
+	if err != nil {
+		return nil, err
+	}
+	e := doc.Environment()
+	e.Name = name
+	return e, nil
+}
+
+func (c *Client) CreateEnvironment(workspaceDir, collection, name string, vars []EnvVar, overwrite bool) (*Environment, error) {
+	path, err := environmentPath(workspaceDir, collection, name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return nil, fmt.Errorf("%w: %q", ErrAlreadyExists, path)
+	}
+	if err := validateEnvVars(vars); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("mkdir environments: %w", err)
+	}
+
+	e := &Environment{Name: name, Variables: append([]EnvVar{}, vars...)}
+	doc := &Document{}
+	doc.SetEnvironment(e)
+	if err := os.WriteFile(path, doc.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("write environment: %w", err)
+	}
+	return e, nil
+}
+
+func (c *Client) UpdateEnvironment(workspaceDir, collection, name string, patch EnvironmentPatch) (*Environment, error) {
+	path, err := environmentPath(workspaceDir, collection, name)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := loadEnvironment(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	e := doc.Environment()
+	e.Name = name
+
+	if len(patch.Remove) > 0 {
+		kept := e.Variables[:0]
+		for _, v := range e.Variables {
+			drop := false
+			for _, r := range patch.Remove {
+				if v.Name == r {
+					drop = true
+					break
+				}
+			}
+			if !drop {
+				kept = append(kept, v)
+			}
+		}
+		e.Variables = kept
+	}
+	for _, v := range patch.Set {
+		replaced := false
+		for i := range e.Variables {
+			if e.Variables[i].Name == v.Name {
+				e.Variables[i] = v
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			e.Variables = append(e.Variables, v)
+		}
+	}
+	if err := validateEnvVars(e.Variables); err != nil {
+		return nil, err
+	}
+
+	newPath := path
+	if patch.Rename != nil && *patch.Rename != name {
+		newPath, err = environmentPath(workspaceDir, collection, *patch.Rename)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(newPath); err == nil {
+			return nil, fmt.Errorf("%w: %q", ErrAlreadyExists, newPath)
+		}
+		e.Name = *patch.Rename
+	}
+
+	doc.SetEnvironment(e)
+	if err := os.WriteFile(newPath, doc.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("write environment: %w", err)
+	}
+	if newPath != path {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove renamed environment: %w", err)
+		}
+	}
+	return e, nil
+}
+
+func (c *Client) DeleteEnvironment(workspaceDir, collection, name string) error {
+	path, err := environmentPath(workspaceDir, collection, name)
+	if err != nil {
+		return err
+	}
+	if !fileExists(path) {
+		return fmt.Errorf("%w: %q", ErrEnvironmentNotFound, name)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("delete environment: %w", err)
+	}
+	return nil
+}
+
+func environmentPath(workspaceDir, collection, name string) (string, error) {
+	n := strings.TrimSuffix(strings.TrimSpace(name), ".bru")
+	if n == "" {
+		return "", fmt.Errorf("%w: empty name", ErrInvalidEnvironment)
+	}
+	if strings.Contains(n, "..") || strings.ContainsAny(n, `/\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEnvironment, name)
+	}
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(colRoot, environmentsDir, n+".bru"), nil
+}
+
+func loadEnvironment(path, name string) (*Document, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %q", ErrEnvironmentNotFound, name)
+		}
+		return nil, fmt.Errorf("read environment: %w", err)
+	}
+	doc, err := ParseDocument(src)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", path, err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,70 @@
+package bruno
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvironments_Lifecycle(t *testing.T) {
+	c, ws := newTestCollection(t)
+
+	_, err := c.CreateEnvironment(ws, "api", "dev", []EnvVar{
+		{Name: "host", Value: "http://localhost:3000"},
+		{Name: "debug", Value: "1", Disabled: true},
+		{Name: "token", Secret: true},
+	}, false)
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+
+	src, _ := os.ReadFile(filepath.Join(ws, "api", "environments", "dev.bru"))
+	want := "vars {\n  host: http://localhost:3000\n  ~debug: 1\n}\n\nvars:secret [\n  token\n]\n"
+	if string(src) != want {
+		t.Fatalf("unexpected environment file:\n%q\nwant\n%q", src, want)
+	}
+
+	if _, err := c.CreateEnvironment(ws, "api", "dev", nil, false); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists got %v", err)
+	}
+	if _, err := c.CreateEnvironment(ws, "api", "bad", []EnvVar{{Name: "s", Value: "x", Secret: true}}, false); !errors.Is(err, ErrInvalidEnvironment) {
+		t.Fatalf("expected ErrInvalidEnvironment got %v", err)
+	}
+
+	staging := "staging"
+	e, err := c.UpdateEnvironment(ws, "api", "dev", EnvironmentPatch{
+		Rename: &staging,
+		Set:    []EnvVar{{Name: "host", Value: "https://staging.example.com"}, {Name: "apiKey", Secret: true}},
+		Remove: []string{"debug"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateEnvironment: %v", err)
+	}
+	if e.Name != "staging" || len(e.Variables) != 3 {
+		t.Fatalf("unexpected environment: %+v", e)
+	}
+
+	names, err := c.ListEnvironments(ws, "api")
+	if err != nil {
+		t.Fatalf("ListEnvironments: %v", err)
+	}
+	if len(names) != 1 || names[0] != "staging" {
+		t.Fatalf("unexpected environments: %v", names)
+	}
+
+	got, err := c.GetEnvironment(ws, "api", "staging")
+	if err != nil {
+		t.Fatalf("GetEnvironment: %v", err)
+	}
+	if got.Variables[0].Value != "https://staging.example.com" || !got.Variables[2].Secret {
+		t.Fatalf("unexpected variables: %+v", got.Variables)
+	}
+
+	if err := c.DeleteEnvironment(ws, "api", "staging"); err != nil {
+		t.Fatalf("DeleteEnvironment: %v", err)
+	}
+	if _, err := c.GetEnvironment(ws, "api", "staging"); !errors.Is(err, ErrEnvironmentNotFound) {
+		t.Fatalf("expected ErrEnvironmentNotFound got %v", err)
+	}
+}
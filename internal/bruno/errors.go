@@ -3,13 +3,27 @@ package bruno
 import "errors"
 
 var (
-	ErrBruNotFound   = errors.New("bru cli not found")
-	ErrCommandFailed = errors.New("bruno command failed")
+	ErrBruNotFound    = errors.New("bru cli not found")
+	ErrUnsupportedCLI = errors.New("unsupported bru cli version")
+	ErrCommandFailed  = errors.New("bruno command failed")
 
 	ErrInvalidCollectionName = errors.New("invalid collection name")
 	ErrInvalidRequestPath    = errors.New("invalid request path")
+	ErrInvalidFolderPath     = errors.New("invalid folder path")
+	ErrInvalidBru            = errors.New("invalid bru file")
+	ErrInvalidConfig         = errors.New("invalid collection config")
+	ErrInvalidEnvironment    = errors.New("invalid environment")
+	ErrInvalidDataFile       = errors.New("invalid iteration data")
 
 	ErrAlreadyExists     = errors.New("already exists")
 	ErrNotACollection    = errors.New("not a bruno collection")
 	ErrCollectionMissing = errors.New("collection not found")
+	ErrRequestNotFound   = errors.New("request not found")
+	ErrFolderNotFound    = errors.New("folder not found")
+	ErrFolderNotEmpty    = errors.New("folder not empty")
+
+	ErrEnvironmentNotFound = errors.New("environment not found")
+
+	ErrUnsupportedAuth = errors.New("unsupported auth mode")
+	ErrRequestFailed   = errors.New("request failed")
 )
@@ -0,0 +1,230 @@
+package bruno
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const folderFile = "folder.bru"
+
+// Folder is a directory inside a collection together with its folder.bru.
+type Folder struct {
+	Path string `json:"path"`
+	Settings
+}
+
+type FolderInfo struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Seq  int    `json:"seq,omitempty"`
+}
+
+type CreateFolderOptions struct {
+	// Settings for the new folder.bru. Meta.Name defaults to the directory
+	// name and Meta.Seq to the next position among sibling folders.
+	Settings Settings
+}
+
+func (c *Client) CreateFolder(workspaceDir, collection, relDir string, opts CreateFolderOptions) (*Folder, error) {
+	colRoot, dir, err := resolveFolder(workspaceDir, collection, relDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%w: %q", ErrAlreadyExists, dir)
+	}
+	if opts.Settings.Auth.Mode != "" {
+		if err := validateAuth(&opts.Settings.Auth); err != nil {
+			return nil, err
+		}
+	}
+
+	s := opts.Settings
+	if strings.TrimSpace(s.Meta.Name) == "" {
+		s.Meta.Name = filepath.Base(dir)
+	}
+	if s.Meta.Seq <= 0 {
+		s.Meta.Seq = nextFolderSeq(filepath.Dir(dir))
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("mkdir folder: %w", err)
+	}
+	f := &SettingsFile{Path: filepath.Join(dir, folderFile), Settings: &s, doc: &Document{}}
+	if err := f.Save(); err != nil {
+		return nil, err
+	}
+	return &Folder{Path: relSlash(colRoot, dir), Settings: s}, nil
+}
+
+// ListFolders returns every folder in a collection, sorted by path. Name and
+// Seq come from folder.bru when present.
+func (c *Client) ListFolders(workspaceDir, collection string) ([]FolderInfo, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []FolderInfo
+	err = filepath.WalkDir(colRoot, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !d.IsDir() || path == colRoot {
+			return nil
+		}
+		if skipCollectionDir(d.Name()) {
+			return fs.SkipDir
+		}
+
+		info := FolderInfo{Path: relSlash(colRoot, path), Name: d.Name()}
+		if fileExists(filepath.Join(path, folderFile)) {
+			f, err := LoadSettingsFile(filepath.Join(path, folderFile))
+			if err != nil {
+				return err
+			}
+			if f.Settings.Meta.Name != "" {
+				info.Name = f.Settings.Meta.Name
+			}
+			info.Seq = f.Settings.Meta.Seq
+		}
+		out = append(out, info)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk collection failed: %w", err)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+func (c *Client) GetFolder(workspaceDir, collection, relDir string) (*Folder, error) {
+	colRoot, dir, err := existingFolder(workspaceDir, collection, relDir)
+	if err != nil {
+		return nil, err
+	}
+	f, err := LoadSettingsFile(filepath.Join(dir, folderFile))
+	if err != nil {
+		return nil, err
+	}
+	if f.Settings.Meta.Name == "" {
+		f.Settings.Meta.Name = filepath.Base(dir)
+	}
+	return &Folder{Path: relSlash(colRoot, dir), Settings: *f.Settings}, nil
+}
+
+// UpdateFolder applies a patch to a folder's folder.bru, creating the file if
+// the folder does not have one yet.
+func (c *Client) UpdateFolder(workspaceDir, collection, relDir string, patch SettingsPatch) (*Folder, error) {
+	colRoot, dir, err := existingFolder(workspaceDir, collection, relDir)
+	if err != nil {
+		return nil, err
+	}
+	f, err := LoadSettingsFile(filepath.Join(dir, folderFile))
+	if err != nil {
+		return nil, err
+	}
+	if f.Settings.Meta.Name == "" {
+		f.Settings.Meta.Name = filepath.Base(dir)
+	}
+	if err := patch.Apply(f.Settings); err != nil {
+		return nil, err
+	}
+	if err := f.Save(); err != nil {
+		return nil, err
+	}
+	return &Folder{Path: relSlash(colRoot, dir), Settings: *f.Settings}, nil
+}
+
+// DeleteFolder removes a folder. Folders holding anything besides folder.bru
+// are only removed when recursive is set.
+func (c *Client) DeleteFolder(workspaceDir, collection, relDir string, recursive bool) (string, error) {
+	colRoot, dir, err := existingFolder(workspaceDir, collection, relDir)
+	if err != nil {
+		return "", err
+	}
+
+	if !recursive {
+		ents, err := os.ReadDir(dir)
+		if err != nil {
+			return "", fmt.Errorf("read folder: %w", err)
+		}
+		for _, e := range ents {
+			if e.Name() != folderFile {
+				return "", fmt.Errorf("%w: %q", ErrFolderNotEmpty, relDir)
+			}
+		}
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return "", fmt.Errorf("delete folder: %w", err)
+	}
+	return relSlash(colRoot, dir), nil
+}
+
+func resolveFolder(workspaceDir, collection, relDir string) (colRoot, dir string, err error) {
+	colRoot, err = collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return "", "", err
+	}
+	relDir = strings.TrimSpace(relDir)
+	if relDir == "" {
+		return "", "", fmt.Errorf("%w: empty path", ErrInvalidFolderPath)
+	}
+	if filepath.IsAbs(relDir) {
+		return "", "", fmt.Errorf("%w: absolute path: %q", ErrInvalidFolderPath, relDir)
+	}
+	dir, err = safeJoin(colRoot, relDir)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidFolderPath, err)
+	}
+	for _, part := range strings.Split(relSlash(colRoot, dir), "/") {
+		if skipCollectionDir(part) {
+			return "", "", fmt.Errorf("%w: reserved directory: %q", ErrInvalidFolderPath, relDir)
+		}
+	}
+	return colRoot, dir, nil
+}
+
+func existingFolder(workspaceDir, collection, relDir string) (colRoot, dir string, err error) {
+	colRoot, dir, err = resolveFolder(workspaceDir, collection, relDir)
+	if err != nil {
+		return "", "", err
+	}
+	if st, err := os.Stat(dir); err != nil || !st.IsDir() {
+		return "", "", fmt.Errorf("%w: %q", ErrFolderNotFound, relDir)
+	}
+	return colRoot, dir, nil
+}
+
+// IgnoredDir reports directories that never hold collection files:
+// node_modules and dot-directories such as .git and the server's own
+// .bruno-mcp.
+func IgnoredDir(name string) bool {
+	return name == "node_modules" || strings.HasPrefix(name, ".")
+}
+
+// skipCollectionDir reports directories inside a collection that never hold
+// requests or folders: the ignored directories and environments.
+func skipCollectionDir(name string) bool {
+	return name == environmentsDir || IgnoredDir(name)
+}
+
+func nextFolderSeq(parent string) int {
+	ents, err := os.ReadDir(parent)
+	if err != nil {
+		return 1
+	}
+	n := 0
+	for _, e := range ents {
+		if e.IsDir() && !skipCollectionDir(e.Name()) {
+			n++
+		}
+	}
+	return n + 1
+}
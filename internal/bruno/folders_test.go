@@ -0,0 +1,67 @@
+package bruno
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFolders_Lifecycle(t *testing.T) {
+	c, ws := newTestCollection(t)
+
+	f, err := c.CreateFolder(ws, "api", "users", CreateFolderOptions{Settings: Settings{
+		Headers: []Pair{{Key: "X-Service", Value: "users"}},
+		Auth:    Auth{Mode: "bearer", Bearer: &BearerAuth{Token: "{{usersToken}}"}},
+	}})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if f.Meta.Name != "users" || f.Meta.Seq != 1 {
+		t.Fatalf("unexpected folder meta: %+v", f.Meta)
+	}
+	if _, err := c.CreateFolder(ws, "api", "users/admin", CreateFolderOptions{}); err != nil {
+		t.Fatalf("CreateFolder nested: %v", err)
+	}
+	if _, err := c.CreateFolder(ws, "api", "users", CreateFolderOptions{}); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists got %v", err)
+	}
+	if _, err := c.CreateFolder(ws, "api", "environments/x", CreateFolderOptions{}); !errors.Is(err, ErrInvalidFolderPath) {
+		t.Fatalf("expected ErrInvalidFolderPath got %v", err)
+	}
+
+	src, _ := os.ReadFile(filepath.Join(ws, "api", "users", "folder.bru"))
+	for _, want := range []string{"meta {\n  name: users\n  seq: 1\n}", "auth {\n  mode: bearer\n}", "auth:bearer {\n  token: {{usersToken}}\n}"} {
+		if !strings.Contains(string(src), want) {
+			t.Fatalf("expected %q in folder.bru:\n%s", want, src)
+		}
+	}
+
+	list, err := c.ListFolders(ws, "api")
+	if err != nil {
+		t.Fatalf("ListFolders: %v", err)
+	}
+	if len(list) != 2 || list[0].Path != "users" || list[1].Path != "users/admin" {
+		t.Fatalf("unexpected folders: %+v", list)
+	}
+
+	name := "Users"
+	got, err := c.UpdateFolder(ws, "api", "users", SettingsPatch{Name: &name, RemoveHeaders: []string{"x-service"}})
+	if err != nil {
+		t.Fatalf("UpdateFolder: %v", err)
+	}
+	if got.Meta.Name != "Users" || len(got.Headers) != 0 || got.Auth.Bearer == nil {
+		t.Fatalf("unexpected folder after update: %+v", got)
+	}
+
+	if _, err := c.DeleteFolder(ws, "api", "users", false); !errors.Is(err, ErrFolderNotEmpty) {
+		t.Fatalf("expected ErrFolderNotEmpty got %v", err)
+	}
+	if _, err := c.DeleteFolder(ws, "api", "users", true); err != nil {
+		t.Fatalf("DeleteFolder: %v", err)
+	}
+	if _, err := c.GetFolder(ws, "api", "users"); !errors.Is(err, ErrFolderNotFound) {
+		t.Fatalf("expected ErrFolderNotFound got %v", err)
+	}
+}
@@ -0,0 +1,467 @@
+package bruno
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Bytes serialises the document. Blocks whose content is unchanged since
+// parsing are written back verbatim, together with the whitespace around
+// them, so an unmodified document reproduces its source byte for byte.
+// Changed and new blocks are rendered in Bruno's canonical layout.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	lastFresh := false
+
+	for i, b := range d.Blocks {
+		switch {
+		case i == 0:
+			// Keep leading bytes only for the block that originally opened the file.
+			if b.orig != nil && b.Pos.Offset == len(b.lead) {
+				buf.WriteString(b.lead)
+			}
+		case b.lead == "":
+			buf.WriteString("\n\n")
+		default:
+			buf.WriteString(b.lead)
+		}
+
+		if b.orig != nil && b.sameContent(b.orig) {
+			buf.WriteString(b.raw)
+			lastFresh = false
+		} else {
+			buf.WriteString(renderBlock(b))
+			lastFresh = true
+		}
+	}
+
+	switch {
+	case !d.parsed:
+		if len(d.Blocks) > 0 {
+			buf.WriteByte('\n')
+		}
+	case lastFresh && !strings.Contains(d.trailing, "\n"):
+		buf.WriteString(d.trailing)
+		buf.WriteByte('\n')
+	default:
+		buf.WriteString(d.trailing)
+	}
+
+	return buf.Bytes()
+}
+
+func (b *Block) clone() *Block {
+	c := &Block{Name: b.Name, Kind: b.Kind, Text: b.Text, Pos: b.Pos}
+	if b.Pairs != nil {
+		c.Pairs = append([]Pair(nil), b.Pairs...)
+	}
+	return c
+}
+
+func (b *Block) sameContent(o *Block) bool {
+	if b.Name != o.Name || b.Kind != o.Kind || b.Text != o.Text || len(b.Pairs) != len(o.Pairs) {
+		return false
+	}
+	for i := range b.Pairs {
+		p, q := b.Pairs[i], o.Pairs[i]
+		if p.Key != q.Key || p.Value != q.Value || p.Disabled != q.Disabled {
+			return false
+		}
+	}
+	return true
+}
+
+func renderBlock(b *Block) string {
+	var sb strings.Builder
+
+	switch b.Kind {
+	case TextBlock:
+		sb.WriteString(b.Name + " {\n")
+		if b.Text != "" {
+			sb.WriteString(indent(b.Text, "  "))
+		}
+		sb.WriteString("\n}")
+
+	case ListBlock:
+		sb.WriteString(b.Name + " [\n")
+		for i, p := range b.Pairs {
+			sb.WriteString("  ")
+			if p.Disabled {
+				sb.WriteByte('~')
+			}
+			sb.WriteString(p.Key)
+			if i < len(b.Pairs)-1 {
+				sb.WriteByte(',')
+			}
+			sb.WriteByte('\n')
+		}
+		sb.WriteString("]")
+
+	default:
+		sb.WriteString(b.Name + " {\n")
+		for _, p := range b.Pairs {
+			sb.WriteString("  ")
+			if p.Disabled {
+				sb.WriteByte('~')
+			}
+			sb.WriteString(quoteKey(p.Key))
+			sb.WriteString(": ")
+			if strings.Contains(p.Value, "\n") {
+				sb.WriteString(multilineQuote + "\n")
+				sb.WriteString(indent(p.Value, "    "))
+				sb.WriteString("\n  " + multilineQuote)
+			} else {
+				sb.WriteString(p.Value)
+			}
+			sb.WriteByte('\n')
+		}
+		sb.WriteString("}")
+	}
+
+	return sb.String()
+}
+
+// indent prefixes every line, including empty ones, the way Bruno does.
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = prefix + l
+	}
+	return strings.Join(lines, "\n")
+}
+
+func quoteKey(k string) string {
+	if k == "" || strings.ContainsAny(k, ": \t\"") || strings.HasPrefix(k, "~") {
+		return `"` + strings.ReplaceAll(k, `"`, `\"`) + `"`
+	}
+	return k
+}
+
+// blockOrder is the order Bruno writes blocks in. New blocks are inserted
+// according to it; blocks not listed sort last.
+var blockOrder = []string{
+	"meta",
+	"", // the http method block
+	"params:query",
+	"params:path",
+	"headers",
+	"auth",
+	"auth:awsv4",
+	"auth:basic",
+	"auth:bearer",
+	"auth:digest",
+	"auth:ntlm",
+	"auth:oauth2",
+	"auth:wsse",
+	"auth:apikey",
+	"body:json",
+	"body:text",
+	"body:xml",
+	"body:sparql",
+	"body:form-urlencoded",
+	"body:multipart-form",
+	"body:graphql",
+	"body:graphql:vars",
+	"vars",
+	"vars:secret",
+	"vars:pre-request",
+	"vars:post-response",
+	"assert",
+	"script:pre-request",
+	"script:post-response",
+	"tests",
+	"docs",
+}
+
+func blockRank(name string) int {
+	if isAllowedMethod(name) {
+		name = ""
+	}
+	for i, n := range blockOrder {
+		if n == name {
+			return i
+		}
+	}
+	return len(blockOrder)
+}
+
+// insert places b before the first block that sorts after it.
+func (d *Document) insert(b *Block) {
+	r := blockRank(b.Name)
+	for i, o := range d.Blocks {
+		if blockRank(o.Name) > r {
+			d.Blocks = append(d.Blocks[:i], append([]*Block{b}, d.Blocks[i:]...)...)
+			return
+		}
+	}
+	d.Blocks = append(d.Blocks, b)
+}
+
+// RemoveBlock deletes every block with the given name.
+func (d *Document) RemoveBlock(name string) {
+	out := d.Blocks[:0]
+	for _, b := range d.Blocks {
+		if b.Name != name {
+			out = append(out, b)
+		}
+	}
+	d.Blocks = out
+}
+
+// SetPairs replaces the pairs of a dict block, creating it if needed. An empty
+// list removes the block.
+func (d *Document) SetPairs(name string, pairs []Pair) {
+	d.setPairs(name, DictBlock, pairs)
+}
+
+// SetList replaces the items of a list block, creating it if needed. An empty
+// list removes the block.
+func (d *Document) SetList(name string, items []Pair) {
+	d.setPairs(name, ListBlock, items)
+}
+
+func (d *Document) setPairs(name string, kind BlockKind, pairs []Pair) {
+	b := d.Block(name)
+	if len(pairs) == 0 {
+		if b != nil && len(b.Pairs) > 0 {
+			d.RemoveBlock(name)
+		}
+		return
+	}
+	if b == nil {
+		b = &Block{Name: name, Kind: kind}
+		d.insert(b)
+	}
+	b.Pairs = append([]Pair(nil), pairs...)
+}
+
+// SetText replaces the content of a text block, creating it if needed. Empty
+// text removes the block.
+func (d *Document) SetText(name, text string) {
+	b := d.Block(name)
+	if text == "" {
+		if b != nil && b.Text != "" {
+			d.RemoveBlock(name)
+		}
+		return
+	}
+	if b == nil {
+		b = &Block{Name: name, Kind: TextBlock}
+		d.insert(b)
+	}
+	b.Text = text
+}
+
+// SetValue sets key in a dict block, keeping the position of an existing
+// entry and every other entry. An empty value removes the key.
+func (d *Document) SetValue(name, key, value string) {
+	b := d.Block(name)
+	if b == nil {
+		if value == "" {
+			return
+		}
+		b = &Block{Name: name, Kind: DictBlock}
+		d.insert(b)
+	}
+	b.Set(key, value)
+}
+
+// Set sets the first pair with the given key, enabling it, or appends a new
+// pair. An empty value removes the key.
+func (b *Block) Set(key, value string) {
+	for i, p := range b.Pairs {
+		if p.Key != key {
+			continue
+		}
+		if value == "" {
+			b.Pairs = append(b.Pairs[:i:i], b.Pairs[i+1:]...)
+			return
+		}
+		b.Pairs[i].Value = value
+		b.Pairs[i].Disabled = false
+		return
+	}
+	if value != "" {
+		b.Pairs = append(b.Pairs, Pair{Key: key, Value: value})
+	}
+}
+
+// SetRequest writes the typed request into the document. Blocks the Request
+// type does not model, and keys it does not know about in meta and the method
+// block, are left as they are.
+func (d *Document) SetRequest(r *Request) {
+	seq := ""
+	if r.Meta.Seq > 0 {
+		seq = strconv.Itoa(r.Meta.Seq)
+	}
+	d.SetValue("meta", "name", r.Meta.Name)
+	d.SetValue("meta", "type", r.Meta.Type)
+	d.SetValue("meta", "seq", seq)
+
+	method := strings.ToLower(r.Method)
+	var httpBlock *Block
+	for _, b := range d.Blocks {
+		if isAllowedMethod(b.Name) {
+			httpBlock = b
+			break
+		}
+	}
+	if method != "" {
+		if httpBlock == nil {
+			httpBlock = &Block{Name: method, Kind: DictBlock}
+			d.insert(httpBlock)
+		}
+		httpBlock.Name = method
+		httpBlock.Set("url", r.URL)
+		httpBlock.Set("body", r.Body.Mode)
+		httpBlock.Set("auth", r.Auth.Mode)
+	}
+
+	query := "params:query"
+	if d.Block("query") != nil && d.Block(query) == nil {
+		query = "query" // legacy name; keep it rather than rewriting the block
+	}
+	d.SetPairs(query, r.Query)
+	d.SetPairs("params:path", r.PathParams)
+	d.SetPairs("headers", r.Headers)
+
+	d.setAuth(r.Auth)
+
+	d.SetText("body:json", r.Body.JSON)
+	d.SetText("body:text", r.Body.Text)
+	d.SetText("body:xml", r.Body.XML)
+	d.SetText("body:sparql", r.Body.SPARQL)
+	d.SetPairs("body:form-urlencoded", r.Body.FormURLEncoded)
+	d.SetPairs("body:multipart-form", r.Body.MultipartForm)
+	var gql GraphQL
+	if r.Body.GraphQL != nil {
+		gql = *r.Body.GraphQL
+	}
+	d.SetText("body:graphql", gql.Query)
+	d.SetText("body:graphql:vars", gql.Variables)
+
+	d.SetPairs("vars:pre-request", r.Vars.PreRequest)
+	d.SetPairs("vars:post-response", r.Vars.PostResponse)
+	d.SetPairs("assert", r.Assertions)
+
+	d.SetText("script:pre-request", r.Scripts.PreRequest)
+	d.SetText("script:post-response", r.Scripts.PostResponse)
+	d.SetText("tests", r.Tests)
+	d.SetText("docs", r.Docs)
+}
+
+func (d *Document) setAuth(a Auth) {
+	typed := map[string][]Pair{}
+	if a.Basic != nil {
+		typed["basic"] = []Pair{{Key: "username", Value: a.Basic.Username}, {Key: "password", Value: a.Basic.Password}}
+	}
+	if a.Digest != nil {
+		typed["digest"] = []Pair{{Key: "username", Value: a.Digest.Username}, {Key: "password", Value: a.Digest.Password}}
+	}
+	if a.Bearer != nil {
+		typed["bearer"] = []Pair{{Key: "token", Value: a.Bearer.Token}}
+	}
+	if a.APIKey != nil {
+		typed["apikey"] = []Pair{{Key: "key", Value: a.APIKey.Key}, {Key: "value", Value: a.APIKey.Value}, {Key: "placement", Value: a.APIKey.Placement}}
+	}
+
+	for _, b := range append([]*Block(nil), d.Blocks...) {
+		if mode, ok := strings.CutPrefix(b.Name, "auth:"); ok {
+			_, isTyped := typed[mode]
+			_, isOther := a.Other[mode]
+			if !isTyped && !isOther {
+				d.RemoveBlock(b.Name)
+			}
+		}
+	}
+
+	others := make([]string, 0, len(a.Other))
+	for mode := range a.Other {
+		others = append(others, mode)
+	}
+	sort.Strings(others)
+	for _, mode := range others {
+		d.SetPairs("auth:"+mode, a.Other[mode])
+	}
+
+	for _, mode := range []string{"basic", "bearer", "digest", "apikey"} {
+		pairs, ok := typed[mode]
+		if !ok {
+			continue
+		}
+		b := d.Block("auth:" + mode)
+		fresh := b == nil
+		if fresh {
+			b = &Block{Name: "auth:" + mode, Kind: DictBlock}
+			d.insert(b)
+		}
+		// Update in place so that keys outside the typed struct survive.
+		for _, p := range pairs {
+			setAuthValue(b, p.Key, p.Value, fresh)
+		}
+	}
+}
+
+// setAuthValue is Block.Set for auth settings, where an empty value is
+// meaningful and written as "key: ". An existing block only gains a key when
+// its value is non-empty.
+func setAuthValue(b *Block, key, value string, fresh bool) {
+	for i, p := range b.Pairs {
+		if p.Key == key {
+			b.Pairs[i].Value = value
+			return
+		}
+	}
+	if fresh || value != "" {
+		b.Pairs = append(b.Pairs, Pair{Key: key, Value: value})
+	}
+}
+
+// FormatRequest renders a request in Bruno's canonical layout.
+func FormatRequest(r *Request) []byte {
+	d := &Document{}
+	d.SetRequest(r)
+	return d.Bytes()
+}
+
+// RequestFile is a request loaded from disk together with the document it
+// was parsed from, so that edits to Request can be saved without disturbing
+// anything the edit did not touch.
+type RequestFile struct {
+	Path    string
+	Request *Request
+	doc     *Document
+}
+
+func LoadRequestFile(path string) (*RequestFile, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read request: %w", err)
+	}
+	doc, err := ParseDocument(src)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", path, err)
+	}
+	r, err := doc.Request()
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", path, err)
+	}
+	return &RequestFile{Path: path, Request: r, doc: doc}, nil
+}
+
+// Bytes returns the file content with the current Request applied.
+func (f *RequestFile) Bytes() []byte {
+	f.doc.SetRequest(f.Request)
+	return f.doc.Bytes()
+}
+
+func (f *RequestFile) Save() error {
+	if err := os.WriteFile(f.Path, f.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write request: %w", err)
+	}
+	return nil
+}
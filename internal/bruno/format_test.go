@@ -0,0 +1,93 @@
+package bruno
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func TestDocument_RoundTripUnchanged(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "roundtrip", "*.bru"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("expected roundtrip fixtures, err=%v", err)
+	}
+
+	for _, f := range files {
+		src, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatalf("read %s: %v", f, err)
+		}
+		doc, err := ParseDocument(src)
+		if err != nil {
+			t.Fatalf("%s: expected nil error got %v", f, err)
+		}
+		if got := doc.Bytes(); !bytes.Equal(got, src) {
+			t.Fatalf("%s: document round trip changed bytes:\n%q\nwant\n%q", f, got, src)
+		}
+
+		rf, err := LoadRequestFile(f)
+		if err != nil {
+			t.Fatalf("%s: expected nil error got %v", f, err)
+		}
+		if got := rf.Bytes(); !bytes.Equal(got, src) {
+			t.Fatalf("%s: request round trip changed bytes:\n%q\nwant\n%q", f, got, src)
+		}
+	}
+}
+
+func TestRequestFile_EditKeepsUntouchedBlocks(t *testing.T) {
+	rf, err := LoadRequestFile(filepath.Join("testdata", "roundtrip", "unknown_blocks.bru"))
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+
+	rf.Request.URL = "https://example.com/v2"
+	rf.Request.Headers = append(rf.Request.Headers, Pair{Key: "Accept", Value: "application/json"})
+
+	checkGolden(t, filepath.Join("testdata", "format", "edited.golden"), rf.Bytes())
+}
+
+func TestFormatRequest_Golden(t *testing.T) {
+	r := &Request{
+		Meta:       Meta{Name: "Create user", Type: "http", Seq: 2},
+		Method:     "post",
+		URL:        "{{baseUrl}}/users",
+		Headers:    []Pair{{Key: "Content-Type", Value: "application/json"}, {Key: "X-Trace", Disabled: true, Value: "1"}},
+		Query:      []Pair{{Key: "dryRun", Value: "true"}},
+		Body:       Body{Mode: BodyJSON, JSON: "{\n  \"name\": \"Ada\"\n}"},
+		Auth:       Auth{Mode: "basic", Basic: &BasicAuth{Username: "ada", Password: ""}},
+		Vars:       Vars{PostResponse: []Pair{{Key: "id", Value: "res.body.id"}}},
+		Assertions: []Pair{{Key: "res.status", Value: "eq 201"}},
+		Docs:       "Creates a user.",
+	}
+	got := FormatRequest(r)
+	checkGolden(t, filepath.Join("testdata", "format", "request.golden"), got)
+
+	back, err := ParseRequest(got)
+	if err != nil {
+		t.Fatalf("expected formatted output to parse, got %v", err)
+	}
+	if back.URL != r.URL || back.Body.JSON != r.Body.JSON || back.Auth.Basic == nil || len(back.Headers) != 2 {
+		t.Fatalf("formatted request did not parse back: %+v", back)
+	}
+}
+
+func checkGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("update golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s mismatch:\n%s\nwant\n%s", path, got, want)
+	}
+}
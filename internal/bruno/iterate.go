@@ -0,0 +1,164 @@
+package bruno
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// IterationRunResult is the outcome of running a collection once per row of
+// an iteration data file.
+type IterationRunResult struct {
+	Collection string            `json:"collection"`
+	Folder     string            `json:"folder,omitempty"`
+	DataFile   string            `json:"dataFile"`
+	Iterations []IterationResult `json:"iterations"`
+	Summary    RunSummary        `json:"summary"`
+	Bailed     bool              `json:"bailed,omitempty"`
+	DurationMs int64             `json:"durationMs"`
+}
+
+// IterationResult is one iteration; Data holds the row's variables.
+type IterationResult struct {
+	Iteration int                  `json:"iteration"`
+	Data      map[string]string    `json:"data"`
+	Run       *CollectionRunResult `json:"run"`
+}
+
+func (s *RunSummary) merge(o RunSummary) {
+	s.TotalRequests += o.TotalRequests
+	s.PassedRequests += o.PassedRequests
+	s.FailedRequests += o.FailedRequests
+	s.ErrorRequests += o.ErrorRequests
+	s.TotalAssertions += o.TotalAssertions
+	s.PassedAssertions += o.PassedAssertions
+	s.FailedAssertions += o.FailedAssertions
+}
+
+// IterateCollection runs a collection or folder once per row of dataFile, a
+// CSV file with a header row or a JSON array of objects, relative to the
+// workspace. Each row's columns are bound as runtime variables on top of
+// opts.Variables; every iteration starts from those variables alone. With
+// Bail, the first failing iteration stops the rest.
+func (c *Client) IterateCollection(ctx context.Context, workspaceDir, collection, dataFile string, opts CollectionRunOptions) (*IterationRunResult, error) {
+	if _, err := collectionRoot(workspaceDir, collection); err != nil {
+		return nil, err
+	}
+	rows, err := loadIterationData(workspaceDir, dataFile)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &IterationRunResult{Collection: collection, Folder: opts.Folder, DataFile: dataFile, Iterations: []IterationResult{}}
+	start := time.Now()
+	for i, row := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+		iterOpts := opts
+		iterOpts.Variables = make(map[string]string, len(opts.Variables)+len(row))
+		for k, v := range opts.Variables {
+			iterOpts.Variables[k] = v
+		}
+		for k, v := range row {
+			iterOpts.Variables[k] = v
+		}
+
+		run, err := c.RunCollection(ctx, workspaceDir, collection, iterOpts)
+		if err != nil {
+			return nil, err
+		}
+		out.Iterations = append(out.Iterations, IterationResult{Iteration: i + 1, Data: row, Run: run})
+		out.Summary.merge(run.Summary)
+
+		failed := run.Summary.FailedRequests+run.Summary.ErrorRequests > 0
+		if opts.Bail && failed {
+			out.Bailed = run.Bailed || i < len(rows)-1
+			break
+		}
+	}
+	out.DurationMs = time.Since(start).Milliseconds()
+	return out, nil
+}
+
+// loadIterationData reads the rows of a .csv or .json data file.
+func loadIterationData(workspaceDir, dataFile string) ([]map[string]string, error) {
+	if strings.TrimSpace(dataFile) == "" {
+		return nil, fmt.Errorf("%w: data file is required", ErrInvalidDataFile)
+	}
+	if filepath.IsAbs(dataFile) {
+		return nil, fmt.Errorf("%w: path must be relative to the workspace: %q", ErrInvalidDataFile, dataFile)
+	}
+	path, err := safeJoin(filepath.Clean(workspaceDir), dataFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDataFile, err)
+	}
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDataFile, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseCSVData(src)
+	case ".json":
+		return parseJSONData(src)
+	default:
+		return nil, fmt.Errorf("%w: unsupported data file type %q, want .csv or .json", ErrInvalidDataFile, filepath.Ext(path))
+	}
+}
+
+func parseCSVData(src []byte) ([]map[string]string, error) {
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(src), "\ufeff"))).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDataFile, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: csv has no header row", ErrInvalidDataFile)
+	}
+	header := records[0]
+	for i, h := range header {
+		header[i] = strings.TrimSpace(h)
+		if header[i] == "" {
+			return nil, fmt.Errorf("%w: csv column %d has no name", ErrInvalidDataFile, i+1)
+		}
+	}
+
+	rows := []map[string]string{}
+	for _, rec := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, h := range header {
+			row[h] = rec[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseJSONData(src []byte) ([]map[string]string, error) {
+	var items []map[string]any
+	if err := json.Unmarshal(src, &items); err != nil {
+		return nil, fmt.Errorf("%w: want a JSON array of objects: %v", ErrInvalidDataFile, err)
+	}
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		row := make(map[string]string, len(item))
+		for k, v := range item {
+			switch t := v.(type) {
+			case string:
+				row[k] = t
+			case nil:
+				row[k] = ""
+			default:
+				row[k] = toText(t)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,67 @@
+package bruno
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestIterateCollection(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/tenants/t2" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c, ws := newTestCollection(t)
+	writeFile(t, filepath.Join(ws, "api", "tenant.bru"), "get {\n  url: "+srv.URL+"/tenants/{{tenantId}}\n}\n\nassert {\n  res.status: eq 200\n}\n")
+	writeFile(t, filepath.Join(ws, "data", "tenants.csv"), "tenantId,region\nt1,eu\nt2,us\nt3,eu\n")
+	writeFile(t, filepath.Join(ws, "data", "tenants.json"), `[{"tenantId": "t1", "n": 1}, {"tenantId": "t3", "n": null}]`)
+
+	res, err := c.IterateCollection(context.Background(), ws, "api", "data/tenants.csv", CollectionRunOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if len(res.Iterations) != 3 || res.Iterations[1].Data["region"] != "us" {
+		t.Fatalf("unexpected iterations: %+v", res.Iterations)
+	}
+	if res.Summary.TotalRequests != 3 || res.Summary.FailedRequests != 1 {
+		t.Fatalf("unexpected summary: %+v", res.Summary)
+	}
+	if len(seen) != 3 || seen[0] != "/tenants/t1" || seen[2] != "/tenants/t3" {
+		t.Fatalf("unexpected requests: %v", seen)
+	}
+
+	res, err = c.IterateCollection(context.Background(), ws, "api", "data/tenants.csv", CollectionRunOptions{Bail: true})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if len(res.Iterations) != 2 || !res.Bailed {
+		t.Fatalf("expected bail after second iteration: %+v", res)
+	}
+
+	res, err = c.IterateCollection(context.Background(), ws, "api", "data/tenants.json", CollectionRunOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if len(res.Iterations) != 2 || res.Iterations[0].Data["n"] != "1" || res.Summary.PassedRequests != 2 {
+		t.Fatalf("unexpected json iterations: %+v", res)
+	}
+
+	writeFile(t, filepath.Join(ws, "data", "bad.csv"), "a,b\n1\n")
+	for _, f := range []string{"data/bad.csv", "data/missing.csv", "../outside.csv", "api/tenant.bru", ""} {
+		if _, err := c.IterateCollection(context.Background(), ws, "api", f, CollectionRunOptions{}); !errors.Is(err, ErrInvalidDataFile) {
+			t.Fatalf("%q: expected ErrInvalidDataFile got %v", f, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package bruno
+
+// JSONReport is the document `bru run --reporter-json` writes: one entry per
+// iteration of the run.
+type JSONReport []JSONReportIteration
+
+type JSONReportIteration struct {
+	IterationIndex int                `json:"iterationIndex"`
+	Summary        JSONReportSummary  `json:"summary"`
+	Results        []JSONReportResult `json:"results"`
+}
+
+type JSONReportSummary struct {
+	TotalRequests    int `json:"totalRequests"`
+	PassedRequests   int `json:"passedRequests"`
+	FailedRequests   int `json:"failedRequests"`
+	SkippedRequests  int `json:"skippedRequests"`
+	ErrorRequests    int `json:"errorRequests"`
+	TotalAssertions  int `json:"totalAssertions"`
+	PassedAssertions int `json:"passedAssertions"`
+	FailedAssertions int `json:"failedAssertions"`
+	TotalTests       int `json:"totalTests"`
+	PassedTests      int `json:"passedTests"`
+	FailedTests      int `json:"failedTests"`
+}
+
+// JSONReportResult is one executed request. Status is "pass", "fail",
+// "error" or "skipped"; Runtime is in seconds.
+type JSONReportResult struct {
+	Test                      JSONReportTest        `json:"test"`
+	Request                   JSONReportRequest     `json:"request"`
+	Response                  JSONReportResponse    `json:"response"`
+	Error                     *string               `json:"error"`
+	Status                    string                `json:"status"`
+	AssertionResults          []JSONReportAssertion `json:"assertionResults"`
+	TestResults               []JSONReportTestCase  `json:"testResults"`
+	PreRequestTestResults     []JSONReportTestCase  `json:"preRequestTestResults,omitempty"`
+	PostResponseTestResults   []JSONReportTestCase  `json:"postResponseTestResults,omitempty"`
+	ShouldStopRunnerExecution bool                  `json:"shouldStopRunnerExecution"`
+	Runtime                   float64               `json:"runtime"`
+	Suitename                 string                `json:"suitename"`
+	IterationIndex            int                   `json:"iterationIndex"`
+}
+
+type JSONReportTest struct {
+	Filename string `json:"filename"`
+}
+
+type JSONReportRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Data    any               `json:"data,omitempty"`
+}
+
+type JSONReportResponse struct {
+	Status       int               `json:"status"`
+	StatusText   string            `json:"statusText"`
+	Headers      map[string]string `json:"headers"`
+	Data         any               `json:"data"`
+	ResponseTime int64             `json:"responseTime"`
+}
+
+type JSONReportAssertion struct {
+	UID        string `json:"uid,omitempty"`
+	LHSExpr    string `json:"lhsExpr"`
+	RHSExpr    string `json:"rhsExpr"`
+	RHSOperand string `json:"rhsOperand"`
+	Operator   string `json:"operator"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// JSONReportTestCase is a test() result from a tests block or script.
+type JSONReportTestCase struct {
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+func (s *JSONReportSummary) add(o JSONReportSummary) {
+	s.TotalRequests += o.TotalRequests
+	s.PassedRequests += o.PassedRequests
+	s.FailedRequests += o.FailedRequests
+	s.SkippedRequests += o.SkippedRequests
+	s.ErrorRequests += o.ErrorRequests
+	s.TotalAssertions += o.TotalAssertions
+	s.PassedAssertions += o.PassedAssertions
+	s.FailedAssertions += o.FailedAssertions
+	s.TotalTests += o.TotalTests
+	s.PassedTests += o.PassedTests
+	s.FailedTests += o.FailedTests
+}
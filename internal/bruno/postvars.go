@@ -0,0 +1,43 @@
+package bruno
+
+import "strings"
+
+// evaluatePostResponseVars evaluates vars:post-response entries such as
+// "token: res.body.data.token" against a response. res.* expressions read
+// the response like assertions do; anything else is interpolated and taken
+// as a literal. Each value is set as a runtime variable in scope, so later
+// entries and the assertions can use it. Undefined values are skipped.
+func evaluatePostResponseVars(pairs []Pair, res *RunResult, scope *Scope) map[string]string {
+	out := map[string]string{}
+	var body any
+	bodyParsed := false
+
+	for _, p := range pairs {
+		if p.Disabled {
+			continue
+		}
+		expr := strings.TrimSpace(p.Value)
+
+		var value any
+		if strings.HasPrefix(expr, "res.") {
+			if !bodyParsed && strings.HasPrefix(expr, "res.body") {
+				body, bodyParsed = responseBody(res), true
+			}
+			v, defined, err := assertionValue(expr, res, body)
+			if err != nil || !defined {
+				continue
+			}
+			value = v
+		} else {
+			value, _ = operandValue(scope.Interpolate(expr))
+		}
+
+		s := toText(value)
+		if value == nil {
+			s = ""
+		}
+		out[p.Key] = s
+		scope.SetRuntime(p.Key, s)
+	}
+	return out
+}
@@ -0,0 +1,231 @@
+package bruno
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Request is the typed form of a request .bru file.
+type Request struct {
+	Meta       Meta    `json:"meta"`
+	Method     string  `json:"method"`
+	URL        string  `json:"url"`
+	Headers    []Pair  `json:"headers,omitempty"`
+	Query      []Pair  `json:"query,omitempty"`
+	PathParams []Pair  `json:"pathParams,omitempty"`
+	Body       Body    `json:"body"`
+	Auth       Auth    `json:"auth"`
+	Vars       Vars    `json:"vars"`
+	Assertions []Pair  `json:"assertions,omitempty"`
+	Scripts    Scripts `json:"scripts"`
+	Tests      string  `json:"tests,omitempty"`
+	Docs       string  `json:"docs,omitempty"`
+
+	// BlockPos records where each block started in the source.
+	BlockPos map[string]Pos `json:"-"`
+}
+
+type Meta struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+	Seq  int    `json:"seq,omitempty"`
+}
+
+// Body holds every body variant present in the file; Mode selects the one
+// that is sent.
+type Body struct {
+	Mode           string   `json:"mode"`
+	JSON           string   `json:"json,omitempty"`
+	Text           string   `json:"text,omitempty"`
+	XML            string   `json:"xml,omitempty"`
+	SPARQL         string   `json:"sparql,omitempty"`
+	FormURLEncoded []Pair   `json:"formUrlEncoded,omitempty"`
+	MultipartForm  []Pair   `json:"multipartForm,omitempty"`
+	GraphQL        *GraphQL `json:"graphql,omitempty"`
+}
+
+type GraphQL struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables,omitempty"`
+}
+
+// Auth holds the auth mode and the settings of each auth:* block. Modes
+// without a typed struct (awsv4, oauth2, ntlm, wsse, ...) land in Other.
+type Auth struct {
+	Mode   string            `json:"mode"`
+	Basic  *BasicAuth        `json:"basic,omitempty"`
+	Bearer *BearerAuth       `json:"bearer,omitempty"`
+	Digest *BasicAuth        `json:"digest,omitempty"`
+	APIKey *APIKeyAuth       `json:"apikey,omitempty"`
+	Other  map[string][]Pair `json:"other,omitempty"`
+}
+
+type BasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type BearerAuth struct {
+	Token string `json:"token"`
+}
+
+type APIKeyAuth struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Placement string `json:"placement,omitempty"`
+}
+
+type Vars struct {
+	PreRequest   []Pair `json:"preRequest,omitempty"`
+	PostResponse []Pair `json:"postResponse,omitempty"`
+}
+
+type Scripts struct {
+	PreRequest   string `json:"preRequest,omitempty"`
+	PostResponse string `json:"postResponse,omitempty"`
+}
+
+// Body modes as written in the http block ("body: json").
+const (
+	BodyNone           = "none"
+	BodyJSON           = "json"
+	BodyText           = "text"
+	BodyXML            = "xml"
+	BodySPARQL         = "sparql"
+	BodyFormURLEncoded = "formUrlEncoded"
+	BodyMultipartForm  = "multipartForm"
+	BodyGraphQL        = "graphql"
+)
+
+// ParseRequest parses a request .bru file.
+func ParseRequest(src []byte) (*Request, error) {
+	doc, err := ParseDocument(src)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Request()
+}
+
+// Request interprets the document as a request file. Blocks that are not part
+// of the request schema are ignored.
+func (d *Document) Request() (*Request, error) {
+	r := &Request{BlockPos: make(map[string]Pos, len(d.Blocks))}
+
+	for _, b := range d.Blocks {
+		if _, seen := r.BlockPos[b.Name]; !seen {
+			r.BlockPos[b.Name] = b.Pos
+		}
+
+		switch {
+		case b.Name == "meta":
+			r.Meta.Name, _ = b.Get("name")
+			r.Meta.Type, _ = b.Get("type")
+			if v, ok := b.Get("seq"); ok && v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, &SyntaxError{Pos: pairPos(b, "seq"), Msg: fmt.Sprintf("seq must be an integer, got %q", v)}
+				}
+				r.Meta.Seq = n
+			}
+
+		case isAllowedMethod(b.Name):
+			if r.Method != "" {
+				return nil, &SyntaxError{Pos: b.Pos, Msg: fmt.Sprintf("duplicate method block %q", b.Name)}
+			}
+			r.Method = b.Name
+			r.URL, _ = b.Get("url")
+			r.Body.Mode, _ = b.Get("body")
+			r.Auth.Mode, _ = b.Get("auth")
+
+		case b.Name == "headers":
+			r.Headers = clonePairs(b.Pairs)
+		case b.Name == "params:query", b.Name == "query":
+			r.Query = clonePairs(b.Pairs)
+		case b.Name == "params:path":
+			r.PathParams = clonePairs(b.Pairs)
+
+		case b.Name == "body:json":
+			r.Body.JSON = b.Text
+		case b.Name == "body:text":
+			r.Body.Text = b.Text
+		case b.Name == "body:xml":
+			r.Body.XML = b.Text
+		case b.Name == "body:sparql":
+			r.Body.SPARQL = b.Text
+		case b.Name == "body:form-urlencoded":
+			r.Body.FormURLEncoded = clonePairs(b.Pairs)
+		case b.Name == "body:multipart-form":
+			r.Body.MultipartForm = clonePairs(b.Pairs)
+		case b.Name == "body:graphql":
+			if r.Body.GraphQL == nil {
+				r.Body.GraphQL = &GraphQL{}
+			}
+			r.Body.GraphQL.Query = b.Text
+		case b.Name == "body:graphql:vars":
+			if r.Body.GraphQL == nil {
+				r.Body.GraphQL = &GraphQL{}
+			}
+			r.Body.GraphQL.Variables = b.Text
+
+		case b.Name == "auth":
+			// folder.bru and collection.bru keep the mode in its own block.
+			r.Auth.Mode, _ = b.Get("mode")
+		case strings.HasPrefix(b.Name, "auth:"):
+			r.Auth.setMode(strings.TrimPrefix(b.Name, "auth:"), b)
+
+		case b.Name == "vars:pre-request":
+			r.Vars.PreRequest = clonePairs(b.Pairs)
+		case b.Name == "vars:post-response":
+			r.Vars.PostResponse = clonePairs(b.Pairs)
+		case b.Name == "assert":
+			r.Assertions = clonePairs(b.Pairs)
+
+		case b.Name == "script:pre-request":
+			r.Scripts.PreRequest = b.Text
+		case b.Name == "script:post-response":
+			r.Scripts.PostResponse = b.Text
+		case b.Name == "tests":
+			r.Tests = b.Text
+		case b.Name == "docs":
+			r.Docs = b.Text
+		}
+	}
+
+	return r, nil
+}
+
+func (a *Auth) setMode(mode string, b *Block) {
+	get := func(k string) string { v, _ := b.Get(k); return v }
+	switch mode {
+	case "basic":
+		a.Basic = &BasicAuth{Username: get("username"), Password: get("password")}
+	case "digest":
+		a.Digest = &BasicAuth{Username: get("username"), Password: get("password")}
+	case "bearer":
+		a.Bearer = &BearerAuth{Token: get("token")}
+	case "apikey":
+		a.APIKey = &APIKeyAuth{Key: get("key"), Value: get("value"), Placement: get("placement")}
+	default:
+		if a.Other == nil {
+			a.Other = make(map[string][]Pair)
+		}
+		a.Other[mode] = clonePairs(b.Pairs)
+	}
+}
+
+func clonePairs(p []Pair) []Pair {
+	if p == nil {
+		return nil
+	}
+	return append([]Pair(nil), p...)
+}
+
+func pairPos(b *Block, key string) Pos {
+	for _, p := range b.Pairs {
+		if p.Key == key {
+			return p.Pos
+		}
+	}
+	return b.Pos
+}
@@ -0,0 +1,208 @@
+package bruno
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type MoveRequestOptions struct {
+	Overwrite bool
+}
+
+// DeleteRequest removes a request file from a collection.
+func (c *Client) DeleteRequest(workspaceDir, collection, relRequestPath string) (string, error) {
+	colRoot, fullPath, err := resolveRequest(workspaceDir, collection, relRequestPath)
+	if err != nil {
+		return "", err
+	}
+	if err := os.Remove(fullPath); err != nil {
+		return "", fmt.Errorf("delete request: %w", err)
+	}
+	return relSlash(colRoot, fullPath), nil
+}
+
+// MoveRequest moves or renames a request within its collection. A new file
+// name becomes the request's meta.name. When the folder changes, the request
+// is appended to the destination's sequence and the source folder is
+// re-sequenced to close the gap.
+func (c *Client) MoveRequest(workspaceDir, collection, fromPath, toPath string, opts MoveRequestOptions) (string, error) {
+	colRoot, src, err := resolveRequest(workspaceDir, collection, fromPath)
+	if err != nil {
+		return "", err
+	}
+	dst, err := requestDestination(colRoot, toPath, opts.Overwrite)
+	if err != nil {
+		return "", err
+	}
+	if dst == src {
+		return relSlash(colRoot, dst), nil
+	}
+
+	f, err := LoadRequestFile(src)
+	if err != nil {
+		return "", err
+	}
+	if filepath.Base(dst) != filepath.Base(src) {
+		f.Request.Meta.Name = requestBaseName(dst)
+	}
+	srcDir, dstDir := filepath.Dir(src), filepath.Dir(dst)
+	if srcDir != dstDir {
+		f.Request.Meta.Seq = nextSeq(dstDir)
+	}
+
+	if err := os.MkdirAll(dstDir, 0o755); err != nil {
+		return "", fmt.Errorf("mkdir request parent: %w", err)
+	}
+	replaced := fileExists(dst)
+	f.Path = dst
+	if err := f.Save(); err != nil {
+		return "", err
+	}
+	if err := os.Remove(src); err != nil {
+		return "", fmt.Errorf("remove moved request: %w", err)
+	}
+
+	if srcDir != dstDir {
+		if err := resequence(srcDir); err != nil {
+			return "", err
+		}
+	}
+	// The overwritten request's seq is gone from the destination folder.
+	if replaced {
+		if err := resequence(dstDir); err != nil {
+			return "", err
+		}
+	}
+	return relSlash(colRoot, dst), nil
+}
+
+// DuplicateRequest copies a request to a new path, naming the copy after its
+// file and appending it to the destination folder's sequence.
+func (c *Client) DuplicateRequest(workspaceDir, collection, fromPath, toPath string, opts MoveRequestOptions) (string, error) {
+	colRoot, src, err := resolveRequest(workspaceDir, collection, fromPath)
+	if err != nil {
+		return "", err
+	}
+	dst, err := requestDestination(colRoot, toPath, opts.Overwrite)
+	if err != nil {
+		return "", err
+	}
+	if dst == src {
+		return "", fmt.Errorf("%w: duplicate target is the source: %q", ErrInvalidRequestPath, toPath)
+	}
+
+	f, err := LoadRequestFile(src)
+	if err != nil {
+		return "", err
+	}
+	f.Request.Meta.Name = requestBaseName(dst)
+	f.Request.Meta.Seq = nextSeq(filepath.Dir(dst))
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return "", fmt.Errorf("mkdir request parent: %w", err)
+	}
+	f.Path = dst
+	if err := f.Save(); err != nil {
+		return "", err
+	}
+	return relSlash(colRoot, dst), nil
+}
+
+func resolveRequest(workspaceDir, collection, relRequestPath string) (colRoot, fullPath string, err error) {
+	colRoot, err = collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return "", "", err
+	}
+	fullPath, err = requestPathIn(colRoot, relRequestPath)
+	if err != nil {
+		return "", "", err
+	}
+	if !fileExists(fullPath) || isReservedBru(colRoot, fullPath) {
+		return "", "", fmt.Errorf("%w: %q", ErrRequestNotFound, relRequestPath)
+	}
+	return colRoot, fullPath, nil
+}
+
+func requestDestination(colRoot, relRequestPath string, overwrite bool) (string, error) {
+	dst, err := requestPathIn(colRoot, relRequestPath)
+	if err != nil {
+		return "", err
+	}
+	if isReservedBru(colRoot, dst) {
+		return "", fmt.Errorf("%w: reserved path: %q", ErrInvalidRequestPath, relRequestPath)
+	}
+	if _, err := os.Stat(dst); err == nil && !overwrite {
+		return "", fmt.Errorf("%w: %q", ErrAlreadyExists, dst)
+	}
+	return dst, nil
+}
+
+// isReservedBru reports whether a .bru path is collection, folder or
+// environment metadata rather than a request.
+func isReservedBru(colRoot, fullPath string) bool {
+	base := filepath.Base(fullPath)
+	if base == "collection.bru" || base == "folder.bru" {
+		return true
+	}
+	rel, err := filepath.Rel(colRoot, fullPath)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(filepath.ToSlash(rel), "environments/")
+}
+
+func requestBaseName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+func relSlash(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// resequence renumbers the requests in dir 1..n, keeping their current order.
+// Files that fail to parse are left alone.
+func resequence(dir string) error {
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read folder: %w", err)
+	}
+
+	var files []*RequestFile
+	for _, e := range ents {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(strings.ToLower(name), ".bru") || name == "collection.bru" || name == "folder.bru" {
+			continue
+		}
+		f, err := LoadRequestFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		files = append(files, f)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := files[i].Request.Meta.Seq, files[j].Request.Meta.Seq
+		if a != b {
+			return a < b
+		}
+		return files[i].Path < files[j].Path
+	})
+
+	for i, f := range files {
+		if f.Request.Meta.Seq == i+1 {
+			continue
+		}
+		f.Request.Meta.Seq = i + 1
+		if err := f.Save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
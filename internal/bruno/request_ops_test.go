@@ -0,0 +1,113 @@
+package bruno
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMoveRequest_ResequencesFolders(t *testing.T) {
+	c, ws := newTestCollection(t)
+	for _, p := range []string{"users/a", "users/b", "users/c", "orders/x"} {
+		if _, err := c.CreateRequest(ws, "api", p, "get", "https://example.com/"+p, CreateRequestOptions{}); err != nil {
+			t.Fatalf("CreateRequest %s: %v", p, err)
+		}
+	}
+
+	got, err := c.MoveRequest(ws, "api", "users/a", "orders/first", MoveRequestOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if got != "orders/first.bru" {
+		t.Fatalf("unexpected new path %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(ws, "api", "users", "a.bru")); !os.IsNotExist(err) {
+		t.Fatalf("expected source to be removed, stat err=%v", err)
+	}
+
+	moved, err := c.GetRequest(ws, "api", "orders/first")
+	if err != nil {
+		t.Fatalf("GetRequest: %v", err)
+	}
+	if moved.Meta.Name != "first" || moved.Meta.Seq != 2 || moved.URL != "https://example.com/users/a" {
+		t.Fatalf("unexpected moved request: %+v", moved.Meta)
+	}
+
+	for name, want := range map[string]int{"users/b": 1, "users/c": 2} {
+		r, err := c.GetRequest(ws, "api", name)
+		if err != nil {
+			t.Fatalf("GetRequest %s: %v", name, err)
+		}
+		if r.Meta.Seq != want {
+			t.Fatalf("%s: expected seq %d got %d", name, want, r.Meta.Seq)
+		}
+	}
+}
+
+func TestMoveRequest_OverwriteResequencesDestination(t *testing.T) {
+	c, ws := newTestCollection(t)
+	for _, p := range []string{"users/a", "orders/x", "orders/y", "orders/z"} {
+		if _, err := c.CreateRequest(ws, "api", p, "get", "https://example.com/"+p, CreateRequestOptions{}); err != nil {
+			t.Fatalf("CreateRequest %s: %v", p, err)
+		}
+	}
+
+	if _, err := c.MoveRequest(ws, "api", "users/a", "orders/x", MoveRequestOptions{Overwrite: true}); err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	for name, want := range map[string]int{"orders/y": 1, "orders/z": 2, "orders/x": 3} {
+		r, err := c.GetRequest(ws, "api", name)
+		if err != nil {
+			t.Fatalf("GetRequest %s: %v", name, err)
+		}
+		if r.Meta.Seq != want {
+			t.Fatalf("%s: expected seq %d got %d", name, want, r.Meta.Seq)
+		}
+	}
+
+	if _, err := c.MoveRequest(ws, "api", "orders/y", "orders/z", MoveRequestOptions{Overwrite: true}); err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	for name, want := range map[string]int{"orders/z": 1, "orders/x": 2} {
+		r, err := c.GetRequest(ws, "api", name)
+		if err != nil {
+			t.Fatalf("GetRequest %s: %v", name, err)
+		}
+		if r.Meta.Seq != want {
+			t.Fatalf("%s: expected seq %d got %d", name, want, r.Meta.Seq)
+		}
+	}
+}
+
+func TestDuplicateAndDeleteRequest(t *testing.T) {
+	c, ws := newTestCollection(t)
+	if _, err := c.CreateRequest(ws, "api", "ping", "get", "https://example.com", CreateRequestOptions{}); err != nil {
+		t.Fatalf("CreateRequest: %v", err)
+	}
+
+	if _, err := c.DuplicateRequest(ws, "api", "ping", "ping", MoveRequestOptions{}); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists got %v", err)
+	}
+	if _, err := c.DuplicateRequest(ws, "api", "ping", "folder", MoveRequestOptions{}); !errors.Is(err, ErrInvalidRequestPath) {
+		t.Fatalf("expected reserved path to be rejected, got %v", err)
+	}
+
+	if _, err := c.DuplicateRequest(ws, "api", "ping", "ping copy", MoveRequestOptions{}); err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	cp, err := c.GetRequest(ws, "api", "ping copy")
+	if err != nil {
+		t.Fatalf("GetRequest: %v", err)
+	}
+	if cp.Meta.Name != "ping copy" || cp.Meta.Seq != 2 {
+		t.Fatalf("unexpected copy meta: %+v", cp.Meta)
+	}
+
+	if _, err := c.DeleteRequest(ws, "api", "ping"); err != nil {
+		t.Fatalf("DeleteRequest: %v", err)
+	}
+	if _, err := c.DeleteRequest(ws, "api", "ping"); !errors.Is(err, ErrRequestNotFound) {
+		t.Fatalf("expected ErrRequestNotFound got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package bruno
+
+import (
+	"errors"
+	"testing"
+)
+
+const sampleRequest = `meta {
+  name: Create user
+  type: http
+  seq: 3
+}
+
+post {
+  url: {{baseUrl}}/users/:org
+  body: json
+  auth: bearer
+}
+
+params:query {
+  verbose: true
+  ~debug: 1
+}
+
+params:path {
+  org: acme
+}
+
+headers {
+  Content-Type: application/json
+}
+
+auth:bearer {
+  token: {{token}}
+}
+
+body:json {
+  {
+    "name": "Ada"
+  }
+}
+
+vars:post-response {
+  userId: res.body.id
+}
+
+assert {
+  res.status: eq 201
+  "res.body.name": eq Ada
+}
+
+script:pre-request {
+  bru.setVar("ts", Date.now());
+}
+
+tests {
+  test("ok", function() {});
+}
+
+docs {
+  Creates a user.
+}
+`
+
+func TestParseRequest_AllBlocks(t *testing.T) {
+	r, err := ParseRequest([]byte(sampleRequest))
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+
+	if r.Meta.Name != "Create user" || r.Meta.Type != "http" || r.Meta.Seq != 3 {
+		t.Fatalf("unexpected meta: %+v", r.Meta)
+	}
+	if r.Method != "post" || r.URL != "{{baseUrl}}/users/:org" {
+		t.Fatalf("unexpected method/url: %s %s", r.Method, r.URL)
+	}
+	if len(r.Query) != 2 || !r.Query[1].Disabled || r.Query[1].Key != "debug" {
+		t.Fatalf("unexpected query: %+v", r.Query)
+	}
+	if len(r.PathParams) != 1 || r.PathParams[0].Value != "acme" {
+		t.Fatalf("unexpected path params: %+v", r.PathParams)
+	}
+	if len(r.Headers) != 1 || r.Headers[0].Key != "Content-Type" {
+		t.Fatalf("unexpected headers: %+v", r.Headers)
+	}
+	if r.Auth.Mode != "bearer" || r.Auth.Bearer == nil || r.Auth.Bearer.Token != "{{token}}" {
+		t.Fatalf("unexpected auth: %+v", r.Auth)
+	}
+	if r.Body.Mode != BodyJSON {
+		t.Fatalf("expected json body mode got %q", r.Body.Mode)
+	}
+	if want := "{\n  \"name\": \"Ada\"\n}"; r.Body.JSON != want {
+		t.Fatalf("unexpected json body:\n%q\nwant\n%q", r.Body.JSON, want)
+	}
+	if len(r.Vars.PostResponse) != 1 || r.Vars.PostResponse[0].Value != "res.body.id" {
+		t.Fatalf("unexpected post-response vars: %+v", r.Vars.PostResponse)
+	}
+	if len(r.Assertions) != 2 || r.Assertions[1].Key != "res.body.name" || r.Assertions[1].Value != "eq Ada" {
+		t.Fatalf("unexpected assertions: %+v", r.Assertions)
+	}
+	if r.Scripts.PreRequest != `bru.setVar("ts", Date.now());` {
+		t.Fatalf("unexpected pre-request script: %q", r.Scripts.PreRequest)
+	}
+	if r.Docs != "Creates a user." {
+		t.Fatalf("unexpected docs: %q", r.Docs)
+	}
+}
+
+func TestParseRequest_Positions(t *testing.T) {
+	r, err := ParseRequest([]byte(sampleRequest))
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if p := r.BlockPos["post"]; p.Line != 7 || p.Col != 1 {
+		t.Fatalf("expected post block at 7:1 got %s", p)
+	}
+	if p := r.Query[1].Pos; p.Line != 15 || p.Col != 3 {
+		t.Fatalf("expected disabled query pair at 15:3 got %s", p)
+	}
+}
+
+func TestParseDocument_ListAndMultiline(t *testing.T) {
+	src := "vars {\n  json: '''\n    {\"a\": 1}\n    {\"b\": 2}\n  '''\n}\n\nvars:secret [\n  token,\n  ~apiKey\n]\n"
+	doc, err := ParseDocument([]byte(src))
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+
+	v, _ := doc.Block("vars").Get("json")
+	if v != "{\"a\": 1}\n{\"b\": 2}" {
+		t.Fatalf("unexpected multiline value: %q", v)
+	}
+
+	sec := doc.Block("vars:secret")
+	if sec == nil || sec.Kind != ListBlock {
+		t.Fatalf("expected vars:secret list block, got %+v", sec)
+	}
+	if len(sec.Pairs) != 2 || sec.Pairs[0].Key != "token" || !sec.Pairs[1].Disabled {
+		t.Fatalf("unexpected secret items: %+v", sec.Pairs)
+	}
+}
+
+func TestParseDocument_SyntaxErrors(t *testing.T) {
+	cases := map[string]struct {
+		src  string
+		line int
+	}{
+		"stray text":    {"meta {\n  name: a\n}\noops\n", 4},
+		"unterminated":  {"meta {\n  name: a\n", 1},
+		"missing colon": {"headers {\n  X-Foo bar\n}\n", 2},
+	}
+	for name, tc := range cases {
+		_, err := ParseDocument([]byte(tc.src))
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("%s: expected SyntaxError got %v", name, err)
+		}
+		if !errors.Is(err, ErrInvalidBru) {
+			t.Fatalf("%s: expected ErrInvalidBru", name)
+		}
+		if se.Pos.Line != tc.line {
+			t.Fatalf("%s: expected error on line %d got %s", name, tc.line, se.Pos)
+		}
+	}
+}
@@ -0,0 +1,546 @@
+package bruno
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultRunTimeout = 30 * time.Second
+	maxResponseBody   = 10 << 20
+)
+
+// RunOptions controls how a request is executed. Variables are runtime
+// variables and win over every variable defined in the collection.
+// Assertions, when set, are checked instead of the request's assert block.
+type RunOptions struct {
+	Environment string            `json:"environment,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+	Assertions  []Pair            `json:"assertions,omitempty"`
+	Timeout     time.Duration     `json:"-"`
+}
+
+// RunResult is the outcome of sending a request.
+type RunResult struct {
+	Request    SentRequest `json:"request"`
+	Status     int         `json:"status"`
+	StatusText string      `json:"statusText"`
+	Headers    http.Header `json:"headers"`
+	// Body is the response body as text, or base64 when BodyEncoding says so.
+	Body         string `json:"body"`
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+	Size         int64  `json:"size"`
+	Truncated    bool   `json:"truncated,omitempty"`
+	DurationMs   int64  `json:"durationMs"`
+
+	// Variables are the runtime variables set by vars:post-response.
+	Variables  map[string]string `json:"variables,omitempty"`
+	Assertions []AssertionResult `json:"assertions,omitempty"`
+}
+
+// Passed reports whether every assertion passed.
+func (r *RunResult) Passed() bool {
+	for _, a := range r.Assertions {
+		if !a.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// SentRequest is the request as it went over the wire, after variables,
+// inherited headers and auth were applied. Credentials added by auth are
+// masked.
+type SentRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RunRequest executes a request file over HTTP. Headers and auth are
+// inherited from collection.bru and the folder.bru files above the request.
+func (c *Client) RunRequest(ctx context.Context, workspaceDir, collection, relRequestPath string, opts RunOptions) (*RunResult, error) {
+	colRoot, fullPath, err := resolveRequest(workspaceDir, collection, relRequestPath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := LoadRequestFile(fullPath)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(fullPath)
+	chain, err := settingsChain(colRoot, dir)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := c.newScope(workspaceDir, collection, colRoot, dir, chain, f.Request, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultRunTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, sent, err := buildHTTPRequest(ctx, colRoot, f.Request, chain, vars)
+	if err != nil {
+		return nil, err
+	}
+	res, err := send(req, sent, effectiveAuth(f.Request.Auth, chain), vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var postVars []Pair
+	for _, st := range chain {
+		postVars = append(postVars, st.Vars.PostResponse...)
+	}
+	postVars = append(postVars, f.Request.Vars.PostResponse...)
+	res.Variables = evaluatePostResponseVars(postVars, res, vars)
+
+	assertions := f.Request.Assertions
+	if opts.Assertions != nil {
+		assertions = opts.Assertions
+	}
+	res.Assertions = EvaluateAssertions(assertions, res, vars)
+	return res, nil
+}
+
+// settingsChain loads collection.bru and every folder.bru from the collection
+// root down to dir, outermost first.
+func settingsChain(colRoot, dir string) ([]*Settings, error) {
+	f, err := LoadSettingsFile(filepath.Join(colRoot, collectionFile))
+	if err != nil {
+		return nil, err
+	}
+	chain := []*Settings{f.Settings}
+
+	rel := relSlash(colRoot, dir)
+	if rel == "." {
+		return chain, nil
+	}
+	cur := colRoot
+	for _, part := range strings.Split(rel, "/") {
+		cur = filepath.Join(cur, part)
+		f, err := LoadSettingsFile(filepath.Join(cur, folderFile))
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, f.Settings)
+	}
+	return chain, nil
+}
+
+// effectiveAuth resolves mode "inherit" to the nearest folder or collection
+// auth that is not itself inherited.
+func effectiveAuth(a Auth, chain []*Settings) Auth {
+	if a.Mode != "inherit" {
+		return a
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if m := chain[i].Auth.Mode; m != "" && m != "inherit" {
+			return chain[i].Auth
+		}
+	}
+	return Auth{Mode: "none"}
+}
+
+func buildHTTPRequest(ctx context.Context, colRoot string, r *Request, chain []*Settings, vars *Scope) (*http.Request, *SentRequest, error) {
+	if r.Method == "" {
+		return nil, nil, fmt.Errorf("%w: request has no method block", ErrInvalidBru)
+	}
+	u, err := requestURL(r, vars)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := http.Header{}
+	for _, s := range chain {
+		setHeaders(header, s.Headers, vars)
+	}
+	setHeaders(header, r.Headers, vars)
+
+	body, shown, contentType, err := requestBody(colRoot, r.Body, vars)
+	if err != nil {
+		return nil, nil, err
+	}
+	if contentType != "" && header.Get("Content-Type") == "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	// The sent request shows credentials set by auth as Redacted: encoded
+	// forms such as basic auth's base64 would slip past the redactor.
+	shownHeader := http.Header{}
+	shownURL := *u
+	auth := effectiveAuth(r.Auth, chain)
+	switch auth.Mode {
+	case "", "none", "inherit", "digest":
+		// digest answers the server's challenge in send.
+	case "basic":
+		if auth.Basic != nil {
+			cred := vars.Interpolate(auth.Basic.Username) + ":" + vars.Interpolate(auth.Basic.Password)
+			header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(cred)))
+			shownHeader.Set("Authorization", "Basic "+Redacted)
+		}
+	case "bearer":
+		if auth.Bearer != nil {
+			header.Set("Authorization", "Bearer "+vars.Interpolate(auth.Bearer.Token))
+			shownHeader.Set("Authorization", "Bearer "+Redacted)
+		}
+	case "apikey":
+		if k := auth.APIKey; k != nil && k.Key != "" {
+			key, value := vars.Interpolate(k.Key), vars.Interpolate(k.Value)
+			if k.Placement == "queryparams" {
+				u.RawQuery = addQuery(u.RawQuery, url.QueryEscape(key)+"="+url.QueryEscape(value))
+				shownURL.RawQuery = addQuery(shownURL.RawQuery, url.QueryEscape(key)+"="+Redacted)
+			} else {
+				header.Set(key, value)
+				shownHeader.Set(key, Redacted)
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnsupportedAuth, auth.Mode)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(r.Method), u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidRequestPath, err)
+	}
+	req.Header = header
+
+	sent := &SentRequest{Method: req.Method, URL: shownURL.String(), Headers: header.Clone(), Body: shown}
+	for k, v := range shownHeader {
+		sent.Headers[k] = v
+	}
+	return req, sent, nil
+}
+
+func addQuery(rawQuery, param string) string {
+	if rawQuery == "" {
+		return param
+	}
+	return rawQuery + "&" + param
+}
+
+// requestURL interpolates the URL and substitutes :name path params. URLs
+// without a scheme are sent over http, as Bruno does.
+func requestURL(r *Request, vars *Scope) (*url.URL, error) {
+	raw := strings.TrimSpace(vars.Interpolate(r.URL))
+	if raw == "" {
+		return nil, fmt.Errorf("%w: empty url", ErrInvalidRequestPath)
+	}
+	if !hasScheme(raw) {
+		raw = "http://" + raw
+	}
+	raw = strings.ReplaceAll(raw, " ", "%20")
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid url: %v", ErrInvalidRequestPath, err)
+	}
+
+	if len(r.PathParams) > 0 {
+		segs := strings.Split(u.EscapedPath(), "/")
+		for i, seg := range segs {
+			name, ok := strings.CutPrefix(seg, ":")
+			if !ok {
+				continue
+			}
+			for _, p := range r.PathParams {
+				if p.Key == name && !p.Disabled {
+					segs[i] = url.PathEscape(vars.Interpolate(p.Value))
+				}
+			}
+		}
+		escaped := strings.Join(segs, "/")
+		path, err := url.PathUnescape(escaped)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid url: %v", ErrInvalidRequestPath, err)
+		}
+		u.Path, u.RawPath = path, escaped
+	}
+	return u, nil
+}
+
+// hasScheme reports whether a URL starts with a scheme; a "://" in the path or
+// query, as in {{host}}/redirect?to=http://x, does not count.
+func hasScheme(raw string) bool {
+	i := strings.Index(raw, "://")
+	return i > 0 && !strings.ContainsAny(raw[:i], "/?#")
+}
+
+func setHeaders(h http.Header, pairs []Pair, vars *Scope) {
+	for _, p := range pairs {
+		if p.Disabled {
+			continue
+		}
+		h.Set(vars.Interpolate(p.Key), vars.Interpolate(p.Value))
+	}
+}
+
+var fileRefRe = regexp.MustCompile(`^@file\((.*)\)$`)
+
+// requestBody encodes the body selected by the body mode and returns the
+// content type it implies. shown is the body as reported back: files of a
+// multipart form are replaced by a placeholder.
+func requestBody(colRoot string, b Body, vars *Scope) (body []byte, shown string, contentType string, err error) {
+	switch b.Mode {
+	case "", BodyNone:
+		return nil, "", "", nil
+	case BodyJSON:
+		body, contentType = []byte(vars.Interpolate(b.JSON)), "application/json"
+	case BodyText:
+		body, contentType = []byte(vars.Interpolate(b.Text)), "text/plain"
+	case BodyXML:
+		body, contentType = []byte(vars.Interpolate(b.XML)), "application/xml"
+	case BodySPARQL:
+		body, contentType = []byte(vars.Interpolate(b.SPARQL)), "application/sparql-query"
+
+	case BodyFormURLEncoded:
+		var parts []string
+		for _, p := range b.FormURLEncoded {
+			if !p.Disabled {
+				parts = append(parts, url.QueryEscape(vars.Interpolate(p.Key))+"="+url.QueryEscape(vars.Interpolate(p.Value)))
+			}
+		}
+		body, contentType = []byte(strings.Join(parts, "&")), "application/x-www-form-urlencoded"
+
+	case BodyMultipartForm:
+		return multipartBody(colRoot, b.MultipartForm, vars)
+
+	case BodyGraphQL:
+		payload := map[string]any{}
+		if b.GraphQL != nil {
+			payload["query"] = vars.Interpolate(b.GraphQL.Query)
+			if v := strings.TrimSpace(vars.Interpolate(b.GraphQL.Variables)); v != "" {
+				payload["variables"] = json.RawMessage(v)
+			}
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("%w: graphql variables: %v", ErrInvalidBru, err)
+		}
+		body, contentType = data, "application/json"
+
+	default:
+		return nil, "", "", fmt.Errorf("%w: unsupported body mode: %q", ErrInvalidRequestPath, b.Mode)
+	}
+	return body, string(body), contentType, nil
+}
+
+// multipartBody encodes a multipart form. @file(...) values must name files
+// inside the collection; their contents are not echoed in shown.
+func multipartBody(colRoot string, fields []Pair, vars *Scope) ([]byte, string, string, error) {
+	var buf, shown bytes.Buffer
+	w, sw := multipart.NewWriter(&buf), multipart.NewWriter(&shown)
+	if err := sw.SetBoundary(w.Boundary()); err != nil {
+		return nil, "", "", err
+	}
+	for _, p := range fields {
+		if p.Disabled {
+			continue
+		}
+		key, value := vars.Interpolate(p.Key), vars.Interpolate(p.Value)
+		m := fileRefRe.FindStringSubmatch(value)
+		if m == nil {
+			if err := w.WriteField(key, value); err != nil {
+				return nil, "", "", err
+			}
+			_ = sw.WriteField(key, value)
+			continue
+		}
+		for _, name := range strings.Split(m[1], "|") {
+			path, err := safeJoin(colRoot, name)
+			if err != nil {
+				return nil, "", "", fmt.Errorf("%w: multipart file %q must be inside the collection", ErrInvalidRequestPath, name)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, "", "", fmt.Errorf("%w: multipart file %q: %v", ErrInvalidRequestPath, name, err)
+			}
+			fw, err := w.CreateFormFile(key, filepath.Base(path))
+			if err != nil {
+				return nil, "", "", err
+			}
+			if _, err := fw.Write(data); err != nil {
+				return nil, "", "", err
+			}
+			if sfw, err := sw.CreateFormFile(key, filepath.Base(path)); err == nil {
+				fmt.Fprintf(sfw, "<file %s, %d bytes>", filepath.Base(path), len(data))
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", "", err
+	}
+	_ = sw.Close()
+	return buf.Bytes(), shown.String(), w.FormDataContentType(), nil
+}
+
+func send(req *http.Request, sent *SentRequest, auth Auth, vars *Scope) (*RunResult, error) {
+	client := &http.Client{}
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRequestFailed, err)
+	}
+	if auth.Mode == "digest" && auth.Digest != nil && resp.StatusCode == http.StatusUnauthorized {
+		if challenge := resp.Header.Get("WWW-Authenticate"); strings.HasPrefix(strings.ToLower(challenge), "digest ") {
+			resp.Body.Close()
+			user, pass := vars.Interpolate(auth.Digest.Username), vars.Interpolate(auth.Digest.Password)
+			authz, err := digestAuthorization(challenge, req.Method, req.URL.RequestURI(), user, pass)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrRequestFailed, err)
+			}
+			retry := req.Clone(req.Context())
+			if req.GetBody != nil {
+				if retry.Body, err = req.GetBody(); err != nil {
+					return nil, fmt.Errorf("%w: %v", ErrRequestFailed, err)
+				}
+			}
+			retry.Header.Set("Authorization", authz)
+			sent.Headers.Set("Authorization", authz)
+			if resp, err = client.Do(retry); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrRequestFailed, err)
+			}
+		}
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: read response: %v", ErrRequestFailed, err)
+	}
+	res := &RunResult{
+		Request:    *sent,
+		Status:     resp.StatusCode,
+		StatusText: http.StatusText(resp.StatusCode),
+		Headers:    resp.Header,
+		Size:       int64(len(data)),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if len(data) > maxResponseBody {
+		data, res.Truncated, res.Size = data[:maxResponseBody], true, maxResponseBody
+	}
+	if utf8.Valid(data) {
+		res.Body = string(data)
+	} else {
+		res.Body, res.BodyEncoding = base64.StdEncoding.EncodeToString(data), "base64"
+	}
+	return res, nil
+}
+
+// digestAuthorization answers an RFC 7616 Digest challenge.
+func digestAuthorization(challenge, method, uri, user, pass string) (string, error) {
+	params := parseAuthParams(challenge[len("digest "):])
+	realm, nonce := params["realm"], params["nonce"]
+	if nonce == "" {
+		return "", fmt.Errorf("digest challenge without nonce")
+	}
+
+	var newHash func() hash.Hash
+	switch algo := strings.ToUpper(params["algorithm"]); strings.TrimSuffix(algo, "-SESS") {
+	case "", "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm %q", params["algorithm"])
+	}
+	h := func(s string) string {
+		d := newHash()
+		io.WriteString(d, s)
+		return hex.EncodeToString(d.Sum(nil))
+	}
+
+	cb := make([]byte, 8)
+	if _, err := rand.Read(cb); err != nil {
+		return "", err
+	}
+	cnonce, nc := hex.EncodeToString(cb), "00000001"
+
+	ha1 := h(user + ":" + realm + ":" + pass)
+	if strings.HasSuffix(strings.ToUpper(params["algorithm"]), "-SESS") {
+		ha1 = h(ha1 + ":" + nonce + ":" + cnonce)
+	}
+	ha2 := h(method + ":" + uri)
+
+	qop := ""
+	for _, q := range strings.Split(params["qop"], ",") {
+		if strings.TrimSpace(q) == "auth" {
+			qop = "auth"
+		}
+	}
+
+	var response string
+	if qop != "" {
+		response = h(ha1 + ":" + nonce + ":" + nc + ":" + cnonce + ":" + qop + ":" + ha2)
+	} else {
+		response = h(ha1 + ":" + nonce + ":" + ha2)
+	}
+
+	out := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", response="%s"`, user, realm, nonce, uri, response)
+	if a := params["algorithm"]; a != "" {
+		out += ", algorithm=" + a
+	}
+	if qop != "" {
+		out += fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, qop, nc, cnonce)
+	}
+	if o := params["opaque"]; o != "" {
+		out += fmt.Sprintf(`, opaque="%s"`, o)
+	}
+	return out, nil
+}
+
+// parseAuthParams splits comma separated key=value pairs whose values may be
+// quoted strings containing commas.
+func parseAuthParams(s string) map[string]string {
+	params := map[string]string{}
+	for s = strings.TrimSpace(s); s != ""; {
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = strings.TrimSpace(s[eq+1:])
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else if i := strings.IndexByte(s, ','); i >= 0 {
+			value, s = s[:i], s[i:]
+		} else {
+			value, s = s, ""
+		}
+		params[key] = strings.TrimSpace(value)
+		s = strings.TrimLeft(strings.TrimSpace(s), ", ")
+	}
+	return params
+}
@@ -0,0 +1,263 @@
+package bruno
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestRunRequest_BuildsRequest(t *testing.T) {
+	var got *http.Request
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.Header().Set("X-Reply", "yes")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"id":7}`)
+	}))
+	defer srv.Close()
+
+	c, ws := newTestCollection(t)
+	col := filepath.Join(ws, "api")
+	writeFile(t, filepath.Join(col, ".env"), "API_TOKEN=tok-123\n")
+	writeFile(t, filepath.Join(col, "collection.bru"), "headers {\n  X-Collection: c\n  X-Override: collection\n}\n\nauth {\n  mode: bearer\n}\n\nauth:bearer {\n  token: {{process.env.API_TOKEN}}\n}\n")
+	writeFile(t, filepath.Join(col, "users", "folder.bru"), "meta {\n  name: users\n}\n\nheaders {\n  X-Folder: f\n}\n")
+	writeFile(t, filepath.Join(col, "users", "create.bru"), `meta {
+  name: create
+  type: http
+  seq: 1
+}
+
+post {
+  url: {{host}}/users/:id?verbose=true
+  body: json
+  auth: inherit
+}
+
+params:query {
+  verbose: true
+}
+
+params:path {
+  id: {{userId}}
+}
+
+headers {
+  X-Override: request
+  ~X-Disabled: nope
+}
+
+body:json {
+  {"name": "{{name}}"}
+}
+
+vars:pre-request {
+  userId: 42
+}
+`)
+	if _, err := c.CreateEnvironment(ws, "api", "local", []EnvVar{{Name: "host", Value: srv.URL}, {Name: "name", Value: "env"}}, false); err != nil {
+		t.Fatalf("CreateEnvironment: %v", err)
+	}
+
+	res, err := c.RunRequest(context.Background(), ws, "api", "users/create", RunOptions{
+		Environment: "local",
+		Variables:   map[string]string{"name": "runtime"},
+	})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+
+	if got.Method != "POST" || got.URL.Path != "/users/42" || got.URL.RawQuery != "verbose=true" {
+		t.Fatalf("unexpected request line: %s %s", got.Method, got.URL)
+	}
+	for k, v := range map[string]string{
+		"X-Collection":  "c",
+		"X-Folder":      "f",
+		"X-Override":    "request",
+		"X-Disabled":    "",
+		"Authorization": "Bearer tok-123",
+		"Content-Type":  "application/json",
+	} {
+		if got.Header.Get(k) != v {
+			t.Fatalf("expected header %s=%q got %q", k, v, got.Header.Get(k))
+		}
+	}
+	if strings.TrimSpace(gotBody) != `{"name": "runtime"}` {
+		t.Fatalf("unexpected body: %q", gotBody)
+	}
+
+	if res.Status != http.StatusCreated || res.StatusText != "Created" || res.Body != `{"id":7}` || res.Headers.Get("X-Reply") != "yes" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if res.Size != 8 || res.Request.URL != srv.URL+"/users/42?verbose=true" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+}
+
+func TestRequestURL_DefaultScheme(t *testing.T) {
+	vars := &Scope{}
+	vars.SetRuntime("host", "localhost:8080")
+	for raw, want := range map[string]string{
+		"{{host}}/redirect?to=http://x": "http://localhost:8080/redirect?to=http://x",
+		"example.com/a#b://c":           "http://example.com/a#b://c",
+		"https://example.com/x":         "https://example.com/x",
+		"{{host}}":                      "http://localhost:8080",
+	} {
+		u, err := requestURL(&Request{URL: raw}, vars)
+		if err != nil {
+			t.Fatalf("%s: %v", raw, err)
+		}
+		if u.String() != want {
+			t.Fatalf("%s: expected %s got %s", raw, want, u.String())
+		}
+	}
+}
+
+func TestRunRequest_BodyModesAndAuth(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		r.ParseMultipartForm(1 << 20)
+	}))
+	defer srv.Close()
+
+	c, ws := newTestCollection(t)
+	col := filepath.Join(ws, "api")
+	writeFile(t, filepath.Join(col, "upload.txt"), "file content")
+
+	writeFile(t, filepath.Join(col, "form.bru"), "post {\n  url: "+srv.URL+"/form\n  body: formUrlEncoded\n  auth: basic\n}\n\nauth:basic {\n  username: user\n  password: pass\n}\n\nbody:form-urlencoded {\n  a: 1 2\n  ~b: 3\n}\n")
+	res, err := c.RunRequest(context.Background(), ws, "api", "form", RunOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if user, pass, ok := got.BasicAuth(); !ok || user != "user" || pass != "pass" {
+		t.Fatalf("expected basic auth got %q %q", user, pass)
+	}
+	if authz := res.Request.Headers.Get("Authorization"); authz != "Basic "+Redacted {
+		t.Fatalf("expected the sent credentials masked, got %q", authz)
+	}
+	if got.PostForm.Get("a") != "1 2" || got.PostForm.Has("b") {
+		t.Fatalf("unexpected form: %v", got.PostForm)
+	}
+
+	writeFile(t, filepath.Join(col, "upload.bru"), "post {\n  url: "+srv.URL+"/upload\n  body: multipartForm\n  auth: apikey\n}\n\nauth:apikey {\n  key: api_key\n  value: k1\n  placement: queryparams\n}\n\nbody:multipart-form {\n  title: hello\n  file: @file(upload.txt)\n}\n")
+	res, err = c.RunRequest(context.Background(), ws, "api", "upload", RunOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if got.URL.Query().Get("api_key") != "k1" || got.MultipartForm.Value["title"][0] != "hello" {
+		t.Fatalf("unexpected multipart request: %s %v", got.URL, got.MultipartForm.Value)
+	}
+	if res.Request.URL != srv.URL+"/upload?api_key="+Redacted {
+		t.Fatalf("expected the sent API key masked, got %s", res.Request.URL)
+	}
+	if fh := got.MultipartForm.File["file"]; len(fh) != 1 || fh[0].Filename != "upload.txt" {
+		t.Fatalf("unexpected multipart files: %v", got.MultipartForm.File)
+	}
+	if strings.Contains(res.Request.Body, "file content") || !strings.Contains(res.Request.Body, "<file upload.txt, 12 bytes>") || !strings.Contains(res.Request.Body, "hello") {
+		t.Fatalf("expected file contents replaced in the sent body, got %q", res.Request.Body)
+	}
+
+	writeFile(t, filepath.Join(ws, "secret"), "top secret")
+	for _, ref := range []string{"../secret", "/etc/passwd"} {
+		writeFile(t, filepath.Join(col, "escape.bru"), "post {\n  url: "+srv.URL+"/upload\n  body: multipartForm\n}\n\nbody:multipart-form {\n  file: @file("+ref+")\n}\n")
+		if _, err := c.RunRequest(context.Background(), ws, "api", "escape", RunOptions{}); !errors.Is(err, ErrInvalidRequestPath) {
+			t.Fatalf("@file(%s): expected ErrInvalidRequestPath got %v", ref, err)
+		}
+	}
+
+	writeFile(t, filepath.Join(col, "aws.bru"), "get {\n  url: "+srv.URL+"\n  auth: awsv4\n}\n")
+	if _, err := c.RunRequest(context.Background(), ws, "api", "aws", RunOptions{}); !errors.Is(err, ErrUnsupportedAuth) {
+		t.Fatalf("expected ErrUnsupportedAuth got %v", err)
+	}
+}
+
+func TestRunRequest_DigestAuth(t *testing.T) {
+	md5hex := func(s string) string { sum := md5.Sum([]byte(s)); return hex.EncodeToString(sum[:]) }
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authz := r.Header.Get("Authorization")
+		if authz == "" {
+			w.Header().Set("WWW-Authenticate", `Digest realm="test", nonce="abc", qop="auth", opaque="xyz"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		p := parseAuthParams(strings.TrimPrefix(authz, "Digest "))
+		ha1 := md5hex("user:test:secret")
+		ha2 := md5hex(r.Method + ":" + p["uri"])
+		if p["response"] != md5hex(ha1+":abc:"+p["nc"]+":"+p["cnonce"]+":auth:"+ha2) || p["opaque"] != "xyz" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	c, ws := newTestCollection(t)
+	writeFile(t, filepath.Join(ws, "api", "digest.bru"), "get {\n  url: "+srv.URL+"/private\n  auth: digest\n}\n\nauth:digest {\n  username: user\n  password: secret\n}\n")
+
+	res, err := c.RunRequest(context.Background(), ws, "api", "digest", RunOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if res.Status != http.StatusOK || res.Body != "ok" {
+		t.Fatalf("expected digest auth to succeed got %d %q", res.Status, res.Body)
+	}
+}
+
+func TestRunRequest_ConnectionFailure(t *testing.T) {
+	c, ws := newTestCollection(t)
+	writeFile(t, filepath.Join(ws, "api", "down.bru"), "get {\n  url: http://127.0.0.1:1/\n}\n")
+	if _, err := c.RunRequest(context.Background(), ws, "api", "down", RunOptions{}); !errors.Is(err, ErrRequestFailed) {
+		t.Fatalf("expected ErrRequestFailed got %v", err)
+	}
+}
+
+func TestRunRequest_PostResponseVars(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "rid-1")
+		io.WriteString(w, `{"data": {"token": "abc", "user": {"id": 9}}}`)
+	}))
+	defer srv.Close()
+
+	c, ws := newTestCollection(t)
+	col := filepath.Join(ws, "api")
+	writeFile(t, filepath.Join(col, "collection.bru"), "vars:post-response {\n  requestId: res.headers.x-request-id\n}\n")
+	writeFile(t, filepath.Join(col, "login.bru"), "post {\n  url: "+srv.URL+"\n}\n\nvars:post-response {\n  token: res.body.data.token\n  user: res.body.data.user\n  bearer: Bearer {{token}}\n  missing: res.body.nope\n  ~skipped: res.status\n}\n\nassert {\n  res.status: eq 200\n  res.body.data.token: eq {{token}}\n}\n")
+
+	res, err := c.RunRequest(context.Background(), ws, "api", "login", RunOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	want := map[string]string{"requestId": "rid-1", "token": "abc", "user": `{"id":9}`, "bearer": "Bearer abc"}
+	if len(res.Variables) != len(want) {
+		t.Fatalf("expected %v got %v", want, res.Variables)
+	}
+	for k, v := range want {
+		if res.Variables[k] != v {
+			t.Fatalf("expected %s=%q got %q", k, v, res.Variables[k])
+		}
+	}
+	if !res.Passed() {
+		t.Fatalf("expected assertions to see post-response vars: %+v", res.Assertions)
+	}
+}
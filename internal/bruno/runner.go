@@ -0,0 +1,294 @@
+package bruno
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CollectionRunOptions controls a collection run. Folder limits the run to a
+// sub-folder. Include and Exclude are globs matched against request paths
+// relative to the collection ("users/*.bru", "**/admin/**"); a request runs
+// when it matches some Include (or Include is empty) and no Exclude.
+type CollectionRunOptions struct {
+	Folder      string            `json:"folder,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+	Bail        bool              `json:"bail,omitempty"`
+	Delay       time.Duration     `json:"-"`
+	Include     []string          `json:"include,omitempty"`
+	Exclude     []string          `json:"exclude,omitempty"`
+	Timeout     time.Duration     `json:"-"`
+}
+
+// CollectionRunResult is the outcome of a collection run.
+type CollectionRunResult struct {
+	Collection  string             `json:"collection"`
+	Folder      string             `json:"folder,omitempty"`
+	Environment string             `json:"environment,omitempty"`
+	Results     []RequestRunResult `json:"results"`
+	Summary     RunSummary         `json:"summary"`
+	// Variables are the runtime variables set during the run.
+	Variables  map[string]string `json:"variables,omitempty"`
+	Bailed     bool              `json:"bailed,omitempty"`
+	DurationMs int64             `json:"durationMs"`
+}
+
+// RequestRunResult is one request of a collection run. Response is nil when
+// the request could not be sent, in which case Error says why.
+type RequestRunResult struct {
+	Path     string     `json:"path"`
+	Name     string     `json:"name"`
+	Response *RunResult `json:"response,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// Passed reports whether the request was sent and all its assertions passed.
+func (r *RequestRunResult) Passed() bool {
+	return r.Error == "" && r.Response != nil && r.Response.Passed()
+}
+
+// RunSummary aggregates a collection run. A request fails when one of its
+// assertions fails and errors when it could not be sent.
+type RunSummary struct {
+	TotalRequests    int `json:"totalRequests"`
+	PassedRequests   int `json:"passedRequests"`
+	FailedRequests   int `json:"failedRequests"`
+	ErrorRequests    int `json:"errorRequests"`
+	TotalAssertions  int `json:"totalAssertions"`
+	PassedAssertions int `json:"passedAssertions"`
+	FailedAssertions int `json:"failedAssertions"`
+}
+
+// Add counts one request result and its assertions. Runs, reports and the
+// run history all summarize through it so that their totals agree.
+func (s *RunSummary) Add(r *RequestRunResult) {
+	s.TotalRequests++
+	switch {
+	case r.Error != "":
+		s.ErrorRequests++
+	case r.Passed():
+		s.PassedRequests++
+	default:
+		s.FailedRequests++
+	}
+	if r.Response != nil {
+		for _, a := range r.Response.Assertions {
+			s.TotalAssertions++
+			if a.Passed() {
+				s.PassedAssertions++
+			} else {
+				s.FailedAssertions++
+			}
+		}
+	}
+}
+
+// RunCollection runs the requests of a collection, or of one of its folders,
+// in Bruno runner order: each folder's requests by meta.seq, then its
+// sub-folders by their folder.bru seq. Runtime variables set by one request
+// are visible to the next. Errors are returned only when the run cannot
+// start; failures of single requests are reported in the result.
+func (c *Client) RunCollection(ctx context.Context, workspaceDir, collection string, opts CollectionRunOptions) (*CollectionRunResult, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+	dir := colRoot
+	if opts.Folder != "" {
+		if _, dir, err = existingFolder(workspaceDir, collection, opts.Folder); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range append(append([]string{}, opts.Include...), opts.Exclude...) {
+		if _, err := globRegexp(p); err != nil {
+			return nil, fmt.Errorf("%w: invalid glob %q: %v", ErrInvalidRequestPath, p, err)
+		}
+	}
+
+	var paths []string
+	if err := runOrder(colRoot, dir, &paths); err != nil {
+		return nil, err
+	}
+	paths = filterPaths(paths, opts.Include, opts.Exclude)
+
+	out := &CollectionRunResult{
+		Collection:  collection,
+		Folder:      opts.Folder,
+		Environment: opts.Environment,
+		Results:     []RequestRunResult{},
+		Variables:   map[string]string{},
+	}
+	runtime := map[string]string{}
+	for k, v := range opts.Variables {
+		runtime[k] = v
+	}
+
+	start := time.Now()
+	for i, rel := range paths {
+		if i > 0 && opts.Delay > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(opts.Delay):
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		rr := RequestRunResult{Path: rel, Name: requestBaseName(rel)}
+		if f, err := LoadRequestFile(filepath.Join(colRoot, filepath.FromSlash(rel))); err == nil && f.Request.Meta.Name != "" {
+			rr.Name = f.Request.Meta.Name
+		}
+
+		res, err := c.RunRequest(ctx, workspaceDir, collection, rel, RunOptions{
+			Environment: opts.Environment,
+			Variables:   runtime,
+			Timeout:     opts.Timeout,
+		})
+		if err != nil {
+			rr.Error = err.Error()
+		} else {
+			rr.Response = res
+			for k, v := range res.Variables {
+				runtime[k] = v
+				out.Variables[k] = v
+			}
+		}
+		out.Results = append(out.Results, rr)
+		out.Summary.Add(&rr)
+
+		if opts.Bail && !rr.Passed() {
+			out.Bailed = i < len(paths)-1
+			break
+		}
+	}
+	out.DurationMs = time.Since(start).Milliseconds()
+	return out, nil
+}
+
+// runOrder appends the request paths under dir, relative to colRoot, in
+// runner order.
+func runOrder(colRoot, dir string, paths *[]string) error {
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read folder: %w", err)
+	}
+
+	type item struct {
+		path string
+		seq  int
+	}
+	var reqs, folders []item
+	for _, e := range ents {
+		name := e.Name()
+		full := filepath.Join(dir, name)
+		switch {
+		case e.IsDir():
+			if skipCollectionDir(name) {
+				continue
+			}
+			seq := 0
+			if f, err := LoadSettingsFile(filepath.Join(full, folderFile)); err == nil {
+				seq = f.Settings.Meta.Seq
+			}
+			folders = append(folders, item{full, seq})
+		case filepath.Ext(name) == ".bru" && !isReservedBru(colRoot, full):
+			f, err := LoadRequestFile(full)
+			if err != nil {
+				// Unparseable files still run, so that the error is reported.
+				reqs = append(reqs, item{full, 0})
+				continue
+			}
+			reqs = append(reqs, item{full, f.Request.Meta.Seq})
+		}
+	}
+
+	bySeq := func(items []item) {
+		sort.SliceStable(items, func(i, j int) bool {
+			a, b := items[i], items[j]
+			if (a.seq > 0) != (b.seq > 0) {
+				return a.seq > 0
+			}
+			if a.seq != b.seq {
+				return a.seq < b.seq
+			}
+			return a.path < b.path
+		})
+	}
+	bySeq(reqs)
+	bySeq(folders)
+
+	for _, r := range reqs {
+		*paths = append(*paths, relSlash(colRoot, r.path))
+	}
+	for _, f := range folders {
+		if err := runOrder(colRoot, f.path, paths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func filterPaths(paths, include, exclude []string) []string {
+	matchAny := func(patterns []string, p string) bool {
+		for _, pat := range patterns {
+			re, _ := globRegexp(pat)
+			if re.MatchString(p) || re.MatchString(strings.TrimSuffix(p, ".bru")) {
+				return true
+			}
+		}
+		return false
+	}
+
+	out := []string{}
+	for _, p := range paths {
+		if len(include) > 0 && !matchAny(include, p) {
+			continue
+		}
+		if matchAny(exclude, p) {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// globRegexp compiles a slash-separated glob: * and ? stay within a path
+// segment, ** spans segments.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, errors.New("empty pattern")
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// "**/" also matches no directory at all.
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
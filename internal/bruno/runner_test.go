@@ -0,0 +1,115 @@
+package bruno
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func newRunnerCollection(t *testing.T, url string) (*Client, string) {
+	t.Helper()
+	c, ws := newTestCollection(t)
+	col := filepath.Join(ws, "api")
+	req := func(rel, name string, seq int, extra string) {
+		writeFile(t, filepath.Join(col, rel), "meta {\n  name: "+name+"\n  seq: "+strconv.Itoa(seq)+"\n}\n\nget {\n  url: "+url+"/"+name+"\n}\n"+extra)
+	}
+	req("login.bru", "login", 1, "\nvars:post-response {\n  token: res.body.token\n}\n")
+	req("me.bru", "me", 2, "\nheaders {\n  Authorization: {{token}}\n}\n\nassert {\n  res.status: eq 200\n}\n")
+	writeFile(t, filepath.Join(col, "b-folder", "folder.bru"), "meta {\n  name: b-folder\n  seq: 1\n}\n")
+	req("b-folder/second.bru", "second", 2, "")
+	req("b-folder/first.bru", "first", 1, "")
+	writeFile(t, filepath.Join(col, "a-folder", "folder.bru"), "meta {\n  name: a-folder\n  seq: 2\n}\n")
+	req("a-folder/last.bru", "last", 1, "\nassert {\n  res.status: eq 200\n}\n")
+	return c, ws
+}
+
+func TestRunCollection_OrderAndRuntimeVars(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			io.WriteString(w, `{"token":"tok"}`)
+		case "/me":
+			if r.Header.Get("Authorization") != "tok" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		case "/last":
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	c, ws := newRunnerCollection(t, srv.URL)
+
+	res, err := c.RunCollection(context.Background(), ws, "api", CollectionRunOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	var order []string
+	for _, r := range res.Results {
+		order = append(order, r.Path)
+	}
+	want := []string{"login.bru", "me.bru", "b-folder/first.bru", "b-folder/second.bru", "a-folder/last.bru"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("expected order %v got %v", want, order)
+	}
+	if !res.Results[1].Passed() {
+		t.Fatalf("expected token to carry over to me: %+v", res.Results[1].Response)
+	}
+	if res.Variables["token"] != "tok" {
+		t.Fatalf("expected runtime vars in result got %v", res.Variables)
+	}
+	wantSummary := RunSummary{TotalRequests: 5, PassedRequests: 4, FailedRequests: 1, TotalAssertions: 2, PassedAssertions: 1, FailedAssertions: 1}
+	if res.Summary != wantSummary {
+		t.Fatalf("expected summary %+v got %+v", wantSummary, res.Summary)
+	}
+}
+
+func TestRunCollection_FilterBailAndErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	c, ws := newRunnerCollection(t, srv.URL)
+
+	res, err := c.RunCollection(context.Background(), ws, "api", CollectionRunOptions{Include: []string{"**/first", "me", "a-folder/**"}, Exclude: []string{"a-folder/*.bru"}})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if len(res.Results) != 2 || res.Results[0].Path != "me.bru" || res.Results[1].Path != "b-folder/first.bru" {
+		t.Fatalf("unexpected filtered results: %+v", res.Results)
+	}
+
+	res, err = c.RunCollection(context.Background(), ws, "api", CollectionRunOptions{Bail: true})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if len(res.Results) != 2 || !res.Bailed || res.Summary.FailedRequests != 1 {
+		t.Fatalf("expected run to bail after me: %+v", res)
+	}
+
+	res, err = c.RunCollection(context.Background(), ws, "api", CollectionRunOptions{Folder: "b-folder"})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if len(res.Results) != 2 || res.Results[0].Name != "first" {
+		t.Fatalf("unexpected folder run: %+v", res.Results)
+	}
+
+	writeFile(t, filepath.Join(ws, "api", "b-folder", "broken.bru"), "meta {\n  seq: 3\n}\n\nget {\n  url: http://127.0.0.1:1/\n}\n")
+	res, err = c.RunCollection(context.Background(), ws, "api", CollectionRunOptions{Folder: "b-folder"})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if last := res.Results[len(res.Results)-1]; last.Error == "" || res.Summary.ErrorRequests != 1 {
+		t.Fatalf("expected connection error to be reported: %+v", res.Results)
+	}
+
+	if _, err := c.RunCollection(context.Background(), ws, "api", CollectionRunOptions{Folder: "nope"}); !errors.Is(err, ErrFolderNotFound) {
+		t.Fatalf("expected ErrFolderNotFound got %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+package bruno
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const dotEnvFile = ".env"
+
+// Redacted replaces secret values in anything handed back to a client.
+const Redacted = "****"
+
+// minSecretLen is the shortest value that is redacted. Shorter values such
+// as DEBUG=1 would otherwise mangle unrelated output.
+const minSecretLen = 4
+
+var processEnvRe = regexp.MustCompile(`\{\{\s*process\.env\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// DotEnv reads the .env file at the collection root. A missing file is an
+// empty map.
+func (c *Client) DotEnv(workspaceDir, collection string) (map[string]string, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+	return loadDotEnv(colRoot)
+}
+
+func loadDotEnv(colRoot string) (map[string]string, error) {
+	src, err := os.ReadFile(filepath.Join(colRoot, dotEnvFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return parseDotEnv(src), nil
+}
+
+// parseDotEnv parses KEY=VALUE lines. Blank lines, # comments and an
+// "export " prefix are ignored; double-quoted values understand \n, \t, \"
+// and \\, single-quoted values are literal, and unquoted values end at " #".
+func parseDotEnv(src []byte) map[string]string {
+	env := map[string]string{}
+	for _, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			value = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+		env[key] = value
+	}
+	return env
+}
+
+// SecretValues returns the values of a collection that must never be echoed
+// back verbatim: everything in its .env file, and the process environment
+// values its .bru files reference as {{process.env.NAME}}.
+func (c *Client) SecretValues(workspaceDir, collection string) ([]string, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+	dotEnv, err := loadDotEnv(colRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, v := range dotEnv {
+		values = append(values, v)
+	}
+
+	for _, f := range c.scanBruFiles(colRoot) {
+		for _, name := range f.envRefs {
+			if _, ok := dotEnv[name]; ok {
+				continue
+			}
+			if v, ok := os.LookupEnv(name); ok {
+				values = append(values, v)
+			}
+		}
+	}
+	return values, nil
+}
+
+// SecretNames returns the variables that any environment of the collection
+// declares in vars:secret. Their values come from the caller and must be
+// masked like the other secrets.
+func (c *Client) SecretNames(workspaceDir, collection string) ([]string, error) {
+	colRoot, err := collectionRoot(workspaceDir, collection)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var names []string
+	for _, f := range c.scanBruFiles(colRoot) {
+		for _, name := range f.secretNames {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// bruScan is what the secret lookups need from one .bru file.
+type bruScan struct {
+	size        int64
+	modTime     time.Time
+	envRefs     []string // names referenced as {{process.env.NAME}}
+	secretNames []string // vars:secret of an environment file
+}
+
+// scanBruFiles scans every .bru file of a collection: requests, folder.bru,
+// collection.bru and the environments. Files whose size and modification
+// time are unchanged since the previous scan are not read again.
+func (c *Client) scanBruFiles(colRoot string) []bruScan {
+	c.scanMu.Lock()
+	defer c.scanMu.Unlock()
+	if c.scans == nil {
+		c.scans = make(map[string]map[string]bruScan)
+	}
+
+	prev := c.scans[colRoot]
+	next := make(map[string]bruScan)
+	envDir := filepath.Join(colRoot, environmentsDir)
+	_ = filepath.WalkDir(colRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != colRoot && IgnoredDir(d.Name()) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".bru") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		f, ok := prev[path]
+		if !ok || f.size != info.Size() || !f.modTime.Equal(info.ModTime()) {
+			if f, ok = scanBruFile(path, filepath.Dir(path) == envDir); !ok {
+				return nil
+			}
+			f.size, f.modTime = info.Size(), info.ModTime()
+		}
+		next[path] = f
+		return nil
+	})
+	c.scans[colRoot] = next
+
+	files := make([]bruScan, 0, len(next))
+	for _, f := range next {
+		files = append(files, f)
+	}
+	return files
+}
+
+func scanBruFile(path string, environment bool) (bruScan, bool) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return bruScan{}, false
+	}
+	var f bruScan
+	for _, m := range processEnvRe.FindAllStringSubmatch(string(src), -1) {
+		f.envRefs = append(f.envRefs, m[1])
+	}
+	if environment {
+		if doc, err := ParseDocument(src); err == nil {
+			for _, v := range doc.Environment().Variables {
+				if v.Secret {
+					f.secretNames = append(f.secretNames, v.Name)
+				}
+			}
+		}
+	}
+	return f, true
+}
+
+// Redactor remembers secret values and masks them in strings. It is safe for
+// concurrent use.
+type Redactor struct {
+	mu     sync.RWMutex
+	values []string // longest first, so overlapping secrets mask fully
+}
+
+func NewRedactor() *Redactor {
+	return &Redactor{}
+}
+
+// Add registers values to redact. Values shorter than four characters are
+// ignored.
+func (r *Redactor) Add(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := false
+	for _, v := range values {
+		if len(v) < minSecretLen || r.has(v) {
+			continue
+		}
+		r.values = append(r.values, v)
+		changed = true
+	}
+	if changed {
+		sort.SliceStable(r.values, func(i, j int) bool { return len(r.values[i]) > len(r.values[j]) })
+	}
+}
+
+func (r *Redactor) has(v string) bool {
+	for _, s := range r.values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns a copy of the registered values, longest first.
+func (r *Redactor) Values() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.values...)
+}
+
+// Redact replaces every registered value in s with Redacted.
+func (r *Redactor) Redact(s string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, v := range r.values {
+		s = strings.ReplaceAll(s, v, Redacted)
+	}
+	return s
+}
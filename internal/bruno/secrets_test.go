@@ -0,0 +1,86 @@
+package bruno
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	src := "# comment\nTOKEN=abc123\nexport API_KEY = \"line\\nbreak\"\nRAW='a \\n b'\nPLAIN=value # trailing\r\nBROKEN\n\n"
+	env := parseDotEnv([]byte(src))
+
+	want := map[string]string{"TOKEN": "abc123", "API_KEY": "line\nbreak", "RAW": `a \n b`, "PLAIN": "value"}
+	if len(env) != len(want) {
+		t.Fatalf("expected %v got %v", want, env)
+	}
+	for k, v := range want {
+		if env[k] != v {
+			t.Fatalf("expected %s=%q got %q", k, v, env[k])
+		}
+	}
+}
+
+func TestSecretValues(t *testing.T) {
+	c, ws := newTestCollection(t)
+	t.Setenv("BRUNO_MCP_TEST_SECRET", "from-process-env")
+	t.Setenv("BRUNO_MCP_TEST_REQUEST_SECRET", "from-request-file")
+	t.Setenv("BRUNO_MCP_TEST_COLLECTION_SECRET", "from-collection-file")
+
+	if err := os.WriteFile(filepath.Join(ws, "api", ".env"), []byte("TOKEN=dotenv-token\n"), 0o644); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+	if _, err := c.CreateEnvironment(ws, "api", "dev", []EnvVar{
+		{Name: "token", Value: "{{process.env.TOKEN}}"},
+		{Name: "other", Value: "{{ process.env.BRUNO_MCP_TEST_SECRET }}"},
+		{Name: "password", Secret: true},
+	}, false); err != nil {
+		t.Fatalf("CreateEnvironment: %v", err)
+	}
+	writeFile(t, filepath.Join(ws, "api", "users", "get.bru"), "get {\n  url: http://x/?key={{process.env.BRUNO_MCP_TEST_REQUEST_SECRET}}\n}\n")
+	writeFile(t, filepath.Join(ws, "api", "collection.bru"), "headers {\n  x-key: {{process.env.BRUNO_MCP_TEST_COLLECTION_SECRET}}\n}\n")
+
+	values, err := c.SecretValues(ws, "api")
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	sort.Strings(values)
+	if want := []string{"dotenv-token", "from-collection-file", "from-process-env", "from-request-file"}; strings.Join(values, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected secret values: %v", values)
+	}
+
+	names, err := c.SecretNames(ws, "api")
+	if err != nil || len(names) != 1 || names[0] != "password" {
+		t.Fatalf("unexpected secret names: %v %v", names, err)
+	}
+
+	// Later scans pick up edited and removed files and skip ignored directories.
+	writeFile(t, filepath.Join(ws, "api", "users", "get.bru"), "get {\n  url: http://x/\n}\n")
+	if err := os.Remove(filepath.Join(ws, "api", "collection.bru")); err != nil {
+		t.Fatalf("remove collection.bru: %v", err)
+	}
+	writeFile(t, filepath.Join(ws, "api", "node_modules", "pkg", "x.bru"), "{{process.env.BRUNO_MCP_TEST_REQUEST_SECRET}}\n")
+	values, err = c.SecretValues(ws, "api")
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	sort.Strings(values)
+	if want := []string{"dotenv-token", "from-process-env"}; strings.Join(values, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected secret values after edits: %v", values)
+	}
+}
+
+func TestRedactor(t *testing.T) {
+	r := NewRedactor()
+	r.Add("secret", "secret-long", "1", "", "secret")
+
+	if got := r.Values(); len(got) != 2 || got[0] != "secret-long" {
+		t.Fatalf("unexpected values: %v", got)
+	}
+	got := r.Redact("a secret-long and a secret, version 1")
+	if want := "a **** and a ****, version 1"; got != want {
+		t.Fatalf("expected %q got %q", want, got)
+	}
+}
@@ -0,0 +1,171 @@
+package bruno
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Settings is the content of a folder.bru: defaults applied to every request
+// beneath the folder. Meta is only used by folders.
+type Settings struct {
+	Meta    Meta    `json:"meta"`
+	Headers []Pair  `json:"headers,omitempty"`
+	Auth    Auth    `json:"auth"`
+	Vars    Vars    `json:"vars"`
+	Scripts Scripts `json:"scripts"`
+	Tests   string  `json:"tests,omitempty"`
+	Docs    string  `json:"docs,omitempty"`
+}
+
+// Settings interprets the document as a folder.bru.
+func (d *Document) Settings() (*Settings, error) {
+	r, err := d.Request()
+	if err != nil {
+		return nil, err
+	}
+	return &Settings{
+		Meta:    Meta{Name: r.Meta.Name, Seq: r.Meta.Seq},
+		Headers: r.Headers,
+		Auth:    r.Auth,
+		Vars:    r.Vars,
+		Scripts: r.Scripts,
+		Tests:   r.Tests,
+		Docs:    r.Docs,
+	}, nil
+}
+
+// SetSettings writes s into the document, leaving blocks it does not model
+// untouched.
+func (d *Document) SetSettings(s *Settings) {
+	seq := ""
+	if s.Meta.Seq > 0 {
+		seq = strconv.Itoa(s.Meta.Seq)
+	}
+	d.SetValue("meta", "name", s.Meta.Name)
+	d.SetValue("meta", "seq", seq)
+
+	d.SetPairs("headers", s.Headers)
+	d.SetValue("auth", "mode", s.Auth.Mode)
+	d.setAuth(s.Auth)
+
+	d.SetPairs("vars:pre-request", s.Vars.PreRequest)
+	d.SetPairs("vars:post-response", s.Vars.PostResponse)
+
+	d.SetText("script:pre-request", s.Scripts.PreRequest)
+	d.SetText("script:post-response", s.Scripts.PostResponse)
+	d.SetText("tests", s.Tests)
+	d.SetText("docs", s.Docs)
+}
+
+// SettingsFile is a folder.bru loaded for editing. A missing file loads as
+// empty settings and is created by Save.
+type SettingsFile struct {
+	Path     string
+	Settings *Settings
+	doc      *Document
+}
+
+func LoadSettingsFile(path string) (*SettingsFile, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &SettingsFile{Path: path, Settings: &Settings{}, doc: &Document{}}, nil
+		}
+		return nil, fmt.Errorf("read settings: %w", err)
+	}
+	doc, err := ParseDocument(src)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", path, err)
+	}
+	s, err := doc.Settings()
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", path, err)
+	}
+	return &SettingsFile{Path: path, Settings: s, doc: doc}, nil
+}
+
+func (f *SettingsFile) Bytes() []byte {
+	f.doc.SetSettings(f.Settings)
+	return f.doc.Bytes()
+}
+
+func (f *SettingsFile) Save() error {
+	if err := os.WriteFile(f.Path, f.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write settings: %w", err)
+	}
+	return nil
+}
+
+// SettingsPatch is a partial change to folder settings, with the same
+// semantics as RequestPatch.
+type SettingsPatch struct {
+	Name *string `json:"name,omitempty"`
+	Seq  *int    `json:"seq,omitempty"`
+
+	SetHeaders    []Pair   `json:"setHeaders,omitempty"`
+	RemoveHeaders []string `json:"removeHeaders,omitempty"`
+
+	Auth *Auth `json:"auth,omitempty"`
+
+	SetPreRequestVars      []Pair   `json:"setPreRequestVars,omitempty"`
+	RemovePreRequestVars   []string `json:"removePreRequestVars,omitempty"`
+	SetPostResponseVars    []Pair   `json:"setPostResponseVars,omitempty"`
+	RemovePostResponseVars []string `json:"removePostResponseVars,omitempty"`
+
+	PreRequestScript   *string `json:"preRequestScript,omitempty"`
+	PostResponseScript *string `json:"postResponseScript,omitempty"`
+	Tests              *string `json:"tests,omitempty"`
+	Docs               *string `json:"docs,omitempty"`
+}
+
+func (p *SettingsPatch) Apply(s *Settings) error {
+	if p.Name != nil {
+		n := strings.TrimSpace(*p.Name)
+		if n == "" {
+			return fmt.Errorf("%w: empty name", ErrInvalidFolderPath)
+		}
+		s.Meta.Name = n
+	}
+	if p.Seq != nil {
+		if *p.Seq <= 0 {
+			return fmt.Errorf("%w: seq must be positive, got %d", ErrInvalidFolderPath, *p.Seq)
+		}
+		s.Meta.Seq = *p.Seq
+	}
+
+	for _, set := range [][]Pair{p.SetHeaders, p.SetPreRequestVars, p.SetPostResponseVars} {
+		for _, kv := range set {
+			if strings.TrimSpace(kv.Key) == "" {
+				return fmt.Errorf("%w: empty key in patch", ErrInvalidFolderPath)
+			}
+		}
+	}
+	s.Headers = patchPairs(s.Headers, p.SetHeaders, p.RemoveHeaders, true)
+	s.Vars.PreRequest = patchPairs(s.Vars.PreRequest, p.SetPreRequestVars, p.RemovePreRequestVars, false)
+	s.Vars.PostResponse = patchPairs(s.Vars.PostResponse, p.SetPostResponseVars, p.RemovePostResponseVars, false)
+
+	if p.Auth != nil {
+		if err := validateAuth(p.Auth); err != nil {
+			return err
+		}
+		s.Auth = *p.Auth
+	}
+
+	if p.PreRequestScript != nil {
+		s.Scripts.PreRequest = *p.PreRequestScript
+	}
+	if p.PostResponseScript != nil {
+		s.Scripts.PostResponse = *p.PostResponseScript
+	}
+	if p.Tests != nil {
+		s.Tests = *p.Tests
+	}
+	if p.Docs != nil {
+		s.Docs = *p.Docs
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package bruno
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RequestPatch describes a partial change to a request. Nil and empty fields
+// leave the request untouched. set* entries replace the first pair with the
+// same key (headers match case-insensitively) or are appended; remove* entries
+// drop every pair with that key. Body and Auth replace the whole section.
+// Query changes are mirrored into the URL, and a new URL's query string into
+// the query params, the way the Bruno app keeps them in sync.
+type RequestPatch struct {
+	Name   *string `json:"name,omitempty"`
+	Seq    *int    `json:"seq,omitempty"`
+	Method *string `json:"method,omitempty"`
+	URL    *string `json:"url,omitempty"`
+
+	SetHeaders       []Pair   `json:"setHeaders,omitempty"`
+	RemoveHeaders    []string `json:"removeHeaders,omitempty"`
+	SetQuery         []Pair   `json:"setQuery,omitempty"`
+	RemoveQuery      []string `json:"removeQuery,omitempty"`
+	SetPathParams    []Pair   `json:"setPathParams,omitempty"`
+	RemovePathParams []string `json:"removePathParams,omitempty"`
+
+	Body *Body `json:"body,omitempty"`
+	Auth *Auth `json:"auth,omitempty"`
+
+	SetPreRequestVars      []Pair   `json:"setPreRequestVars,omitempty"`
+	RemovePreRequestVars   []string `json:"removePreRequestVars,omitempty"`
+	SetPostResponseVars    []Pair   `json:"setPostResponseVars,omitempty"`
+	RemovePostResponseVars []string `json:"removePostResponseVars,omitempty"`
+
+	SetAssertions    []Pair   `json:"setAssertions,omitempty"`
+	RemoveAssertions []string `json:"removeAssertions,omitempty"`
+
+	PreRequestScript   *string `json:"preRequestScript,omitempty"`
+	PostResponseScript *string `json:"postResponseScript,omitempty"`
+	Tests              *string `json:"tests,omitempty"`
+	Docs               *string `json:"docs,omitempty"`
+}
+
+// Apply validates the patch and applies it to r.
+func (p *RequestPatch) Apply(r *Request) error {
+	if p.Method != nil {
+		m := strings.ToLower(strings.TrimSpace(*p.Method))
+		if !isAllowedMethod(m) {
+			return fmt.Errorf("%w: unsupported method: %q", ErrInvalidRequestPath, *p.Method)
+		}
+		r.Method = m
+	}
+	if p.URL != nil {
+		u := strings.TrimSpace(*p.URL)
+		if u == "" {
+			return fmt.Errorf("%w: empty url", ErrInvalidRequestPath)
+		}
+		r.URL = u
+	}
+	if p.Name != nil {
+		n := strings.TrimSpace(*p.Name)
+		if n == "" {
+			return fmt.Errorf("%w: empty name", ErrInvalidRequestPath)
+		}
+		r.Meta.Name = n
+	}
+	if p.Seq != nil {
+		if *p.Seq <= 0 {
+			return fmt.Errorf("%w: seq must be positive, got %d", ErrInvalidRequestPath, *p.Seq)
+		}
+		r.Meta.Seq = *p.Seq
+	}
+
+	for _, set := range [][]Pair{p.SetHeaders, p.SetQuery, p.SetPathParams, p.SetPreRequestVars, p.SetPostResponseVars, p.SetAssertions} {
+		for _, kv := range set {
+			if strings.TrimSpace(kv.Key) == "" {
+				return fmt.Errorf("%w: empty key in patch", ErrInvalidRequestPath)
+			}
+		}
+	}
+
+	r.Headers = patchPairs(r.Headers, p.SetHeaders, p.RemoveHeaders, true)
+	switch {
+	case len(p.SetQuery) > 0 || len(p.RemoveQuery) > 0:
+		r.Query = patchPairs(r.Query, p.SetQuery, p.RemoveQuery, false)
+		syncURLQuery(r)
+	case p.URL != nil:
+		// A new URL carries its own query string; keep only the disabled
+		// params, which Bruno does not put in the URL.
+		var q []Pair
+		for _, kv := range r.Query {
+			if kv.Disabled {
+				q = append(q, kv)
+			}
+		}
+		r.Query = append(queryFromURL(r.URL), q...)
+	}
+	r.PathParams = patchPairs(r.PathParams, p.SetPathParams, p.RemovePathParams, false)
+	r.Vars.PreRequest = patchPairs(r.Vars.PreRequest, p.SetPreRequestVars, p.RemovePreRequestVars, false)
+	r.Vars.PostResponse = patchPairs(r.Vars.PostResponse, p.SetPostResponseVars, p.RemovePostResponseVars, false)
+	r.Assertions = patchPairs(r.Assertions, p.SetAssertions, p.RemoveAssertions, false)
+
+	if p.Body != nil {
+		if err := validateBody(p.Body); err != nil {
+			return err
+		}
+		r.Body = *p.Body
+	}
+	if p.Auth != nil {
+		if err := validateAuth(p.Auth); err != nil {
+			return err
+		}
+		r.Auth = *p.Auth
+	}
+
+	if p.PreRequestScript != nil {
+		r.Scripts.PreRequest = *p.PreRequestScript
+	}
+	if p.PostResponseScript != nil {
+		r.Scripts.PostResponse = *p.PostResponseScript
+	}
+	if p.Tests != nil {
+		r.Tests = *p.Tests
+	}
+	if p.Docs != nil {
+		r.Docs = *p.Docs
+	}
+	return nil
+}
+
+func patchPairs(pairs, set []Pair, remove []string, foldCase bool) []Pair {
+	same := func(a, b string) bool {
+		if foldCase {
+			return strings.EqualFold(a, b)
+		}
+		return a == b
+	}
+
+	if len(remove) > 0 {
+		out := pairs[:0:0]
+		for _, p := range pairs {
+			drop := false
+			for _, k := range remove {
+				if same(p.Key, k) {
+					drop = true
+					break
+				}
+			}
+			if !drop {
+				out = append(out, p)
+			}
+		}
+		pairs = out
+	}
+
+	for _, s := range set {
+		s.Pos = Pos{}
+		replaced := false
+		for i := range pairs {
+			if same(pairs[i].Key, s.Key) {
+				pairs[i] = s
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			pairs = append(pairs, s)
+		}
+	}
+	return pairs
+}
+
+// UpdateRequest applies a patch to an existing request file and returns the
+// result. Blocks the patch does not touch are written back unchanged.
+func (c *Client) UpdateRequest(workspaceDir, collection, relRequestPath string, patch RequestPatch) (*Request, error) {
+	_, fullPath, err := resolveRequest(workspaceDir, collection, relRequestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := LoadRequestFile(fullPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := patch.Apply(f.Request); err != nil {
+		return nil, err
+	}
+	if err := f.Save(); err != nil {
+		return nil, err
+	}
+	return f.Request, nil
+}
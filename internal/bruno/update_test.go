@@ -0,0 +1,97 @@
+package bruno
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestCollection(t *testing.T) (*Client, string) {
+	t.Helper()
+	c := &Client{}
+	ws := t.TempDir()
+	if _, err := c.CreateCollection(ws, "api", CreateCollectionOptions{}); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	return c, ws
+}
+
+func TestUpdateRequest_Patch(t *testing.T) {
+	c, ws := newTestCollection(t)
+	full := filepath.Join(ws, "api", "users.bru")
+	src := sampleRequest + "\nsettings {\n  encodeUrl: true\n}\n"
+	if err := os.WriteFile(full, []byte(src), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	method, url := "PUT", "{{baseUrl}}/users/1"
+	r, err := c.UpdateRequest(ws, "api", "users", RequestPatch{
+		Method:        &method,
+		URL:           &url,
+		SetHeaders:    []Pair{{Key: "content-type", Value: "text/plain"}, {Key: "X-Req", Value: "1"}},
+		RemoveQuery:   []string{"debug"},
+		SetAssertions: []Pair{{Key: "res.status", Value: "eq 200"}},
+	})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if r.Method != "put" || r.URL != url+"?verbose=true" {
+		t.Fatalf("unexpected method/url: %s %s", r.Method, r.URL)
+	}
+	if len(r.Headers) != 2 || r.Headers[0].Value != "text/plain" {
+		t.Fatalf("unexpected headers: %+v", r.Headers)
+	}
+	if len(r.Query) != 1 || r.Query[0].Key != "verbose" {
+		t.Fatalf("unexpected query: %+v", r.Query)
+	}
+
+	out, _ := os.ReadFile(full)
+	for _, want := range []string{"put {\n  url: {{baseUrl}}/users/1?verbose=true\n", "res.status: eq 200", "settings {\n  encodeUrl: true\n}", "script:pre-request {\n  bru.setVar(\"ts\", Date.now());\n}"} {
+		if !strings.Contains(string(out), want) {
+			t.Fatalf("expected %q in updated file:\n%s", want, out)
+		}
+	}
+}
+
+func TestUpdateRequest_InvalidPatch(t *testing.T) {
+	c, ws := newTestCollection(t)
+	if _, err := c.CreateRequest(ws, "api", "a", "get", "https://example.com", CreateRequestOptions{}); err != nil {
+		t.Fatalf("CreateRequest: %v", err)
+	}
+	before, _ := os.ReadFile(filepath.Join(ws, "api", "a.bru"))
+
+	bad := "fetch"
+	_, err := c.UpdateRequest(ws, "api", "a", RequestPatch{Method: &bad})
+	if !errors.Is(err, ErrInvalidRequestPath) {
+		t.Fatalf("expected ErrInvalidRequestPath got %v", err)
+	}
+
+	after, _ := os.ReadFile(filepath.Join(ws, "api", "a.bru"))
+	if string(before) != string(after) {
+		t.Fatalf("file changed after rejected patch")
+	}
+
+	if _, err := c.UpdateRequest(ws, "api", "missing", RequestPatch{}); !errors.Is(err, ErrRequestNotFound) {
+		t.Fatalf("expected ErrRequestNotFound got %v", err)
+	}
+}
+
+func TestUpdateRequest_URLResetsQuery(t *testing.T) {
+	c, ws := newTestCollection(t)
+	if _, err := c.CreateRequest(ws, "api", "a", "get", "https://example.com", CreateRequestOptions{
+		Query: []Pair{{Key: "a", Value: "1"}, {Key: "off", Value: "x", Disabled: true}},
+	}); err != nil {
+		t.Fatalf("CreateRequest: %v", err)
+	}
+
+	url := "https://example.com/v2?b=2"
+	r, err := c.UpdateRequest(ws, "api", "a", RequestPatch{URL: &url})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if len(r.Query) != 2 || r.Query[0].Key != "b" || r.Query[1].Key != "off" {
+		t.Fatalf("unexpected query after url change: %+v", r.Query)
+	}
+}
@@ -0,0 +1,271 @@
+package bruno
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Variable sources, from highest to lowest precedence.
+const (
+	SourceRuntime     = "runtime"
+	SourceRequest     = "request"
+	SourceFolder      = "folder"
+	SourceCollection  = "collection"
+	SourceEnvironment = "environment"
+	SourceProcessEnv  = "process.env"
+	// SourceDynamic marks generated values such as {{$randomUUID}}.
+	SourceDynamic = "dynamic"
+)
+
+var varRe = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// maxInterpolationDepth bounds how often variables referring to other
+// variables are expanded, so that cycles terminate.
+const maxInterpolationDepth = 10
+
+// Scope resolves {{name}} placeholders the way Bruno does: runtime vars win
+// over request vars, then folder vars (innermost first), collection vars,
+// environment vars and finally process.env.NAME from the collection's .env
+// file or the server's environment.
+type Scope struct {
+	layers     []varLayer // highest precedence first
+	processEnv map[string]string
+	secrets    *Redactor // receives the process.env values it resolves; may be nil
+}
+
+type varLayer struct {
+	source string
+	folder string
+	vars   map[string]string
+}
+
+// Resolution explains how a string was interpolated.
+type Resolution struct {
+	Input     string        `json:"input"`
+	Value     string        `json:"value"`
+	Variables []ResolvedVar `json:"variables"`
+}
+
+// ResolvedVar is one placeholder met while resolving, including those nested
+// in other variables' values. Unresolved placeholders are left in the output
+// as written.
+type ResolvedVar struct {
+	Name     string `json:"name"`
+	Value    string `json:"value,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Folder   string `json:"folder,omitempty"`
+	Resolved bool   `json:"resolved"`
+}
+
+// newScope builds the variables visible to a request in reqDir. r may be nil
+// when no request is involved. chain is the settingsChain for reqDir.
+func (c *Client) newScope(workspaceDir, collection, colRoot, reqDir string, chain []*Settings, r *Request, opts RunOptions) (*Scope, error) {
+	s := &Scope{secrets: c.secrets}
+	runtime := map[string]string{}
+	for k, v := range opts.Variables {
+		runtime[k] = v
+	}
+	s.layers = append(s.layers, varLayer{source: SourceRuntime, vars: runtime})
+	if r != nil {
+		s.addPairs(SourceRequest, "", r.Vars.PreRequest)
+	}
+
+	var folders []string
+	if rel := relSlash(colRoot, reqDir); rel != "." {
+		parts := strings.Split(rel, "/")
+		for i := range parts {
+			folders = append(folders, strings.Join(parts[:i+1], "/"))
+		}
+	}
+	for i := len(chain) - 1; i >= 1; i-- {
+		folder := ""
+		if i-1 < len(folders) {
+			folder = folders[i-1]
+		}
+		s.addPairs(SourceFolder, folder, chain[i].Vars.PreRequest)
+	}
+	if len(chain) > 0 {
+		s.addPairs(SourceCollection, "", chain[0].Vars.PreRequest)
+	}
+
+	if opts.Environment != "" {
+		path, err := environmentPath(workspaceDir, collection, opts.Environment)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := loadEnvironment(path, opts.Environment)
+		if err != nil {
+			return nil, err
+		}
+		env := map[string]string{}
+		for _, v := range doc.Environment().Variables {
+			if !v.Disabled && !v.Secret {
+				env[v.Name] = v.Value
+			}
+		}
+		s.add(SourceEnvironment, "", env)
+	}
+
+	dotEnv, err := loadDotEnv(colRoot)
+	if err != nil {
+		return nil, fmt.Errorf("read .env: %w", err)
+	}
+	s.processEnv = dotEnv
+	return s, nil
+}
+
+func (s *Scope) add(source, folder string, vars map[string]string) {
+	if len(vars) > 0 {
+		s.layers = append(s.layers, varLayer{source: source, folder: folder, vars: vars})
+	}
+}
+
+func (s *Scope) addPairs(source, folder string, pairs []Pair) {
+	vars := map[string]string{}
+	for _, p := range pairs {
+		if !p.Disabled {
+			vars[p.Key] = p.Value
+		}
+	}
+	s.add(source, folder, vars)
+}
+
+// SetRuntime sets a runtime variable, which wins over every other layer.
+func (s *Scope) SetRuntime(name, value string) {
+	if len(s.layers) == 0 || s.layers[0].source != SourceRuntime {
+		s.layers = append([]varLayer{{source: SourceRuntime, vars: map[string]string{}}}, s.layers...)
+	}
+	s.layers[0].vars[name] = value
+}
+
+// Lookup returns the raw value of a variable and where it came from.
+func (s *Scope) Lookup(name string) (ResolvedVar, bool) {
+	if strings.HasPrefix(name, "$") {
+		v, ok := dynamicVar(name)
+		return ResolvedVar{Name: name, Value: v, Source: SourceDynamic, Resolved: ok}, ok
+	}
+	if env, ok := strings.CutPrefix(name, "process.env."); ok {
+		v, ok := s.processEnv[env]
+		if !ok {
+			v, ok = os.LookupEnv(env)
+		}
+		if ok && s.secrets != nil {
+			s.secrets.Add(v)
+		}
+		return ResolvedVar{Name: name, Value: v, Source: SourceProcessEnv, Resolved: ok}, ok
+	}
+	for _, l := range s.layers {
+		if v, ok := l.vars[name]; ok {
+			return ResolvedVar{Name: name, Value: v, Source: l.source, Folder: l.folder, Resolved: true}, true
+		}
+	}
+	return ResolvedVar{Name: name}, false
+}
+
+// Interpolate replaces every placeholder it can resolve.
+func (s *Scope) Interpolate(str string) string {
+	return s.expand(str, 0, nil)
+}
+
+// Resolve interpolates str and records every variable it used.
+func (s *Scope) Resolve(str string) *Resolution {
+	res := &Resolution{Input: str, Variables: []ResolvedVar{}}
+	res.Value = s.expand(str, 0, func(v ResolvedVar) {
+		for _, seen := range res.Variables {
+			if seen.Name == v.Name {
+				return
+			}
+		}
+		res.Variables = append(res.Variables, v)
+	})
+	return res
+}
+
+func (s *Scope) expand(str string, depth int, record func(ResolvedVar)) string {
+	if depth >= maxInterpolationDepth || !strings.Contains(str, "{{") {
+		return str
+	}
+	return varRe.ReplaceAllStringFunc(str, func(m string) string {
+		v, ok := s.Lookup(varRe.FindStringSubmatch(m)[1])
+		if record != nil {
+			record(v)
+		}
+		if !ok {
+			return m
+		}
+		return s.expand(v.Value, depth+1, record)
+	})
+}
+
+// dynamicVar generates the value of a {{$name}} helper.
+func dynamicVar(name string) (string, bool) {
+	switch name {
+	case "$randomUUID", "$guid":
+		return newUUID(), true
+	case "$timestamp":
+		return strconv.FormatInt(time.Now().Unix(), 10), true
+	case "$isoTimestamp":
+		return time.Now().UTC().Format("2006-01-02T15:04:05.000Z"), true
+	case "$randomInt":
+		n, _ := rand.Int(rand.Reader, big.NewInt(1001))
+		return n.String(), true
+	}
+	return "", false
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// ResolveVariables interpolates input in the scope of a request, or of the
+// collection alone when relRequestPath is empty. An empty input resolves the
+// request's URL.
+func (c *Client) ResolveVariables(workspaceDir, collection, relRequestPath, input string, opts RunOptions) (*Resolution, error) {
+	var (
+		colRoot, dir string
+		r            *Request
+		err          error
+	)
+	if relRequestPath != "" {
+		var fullPath string
+		colRoot, fullPath, err = resolveRequest(workspaceDir, collection, relRequestPath)
+		if err != nil {
+			return nil, err
+		}
+		f, err := LoadRequestFile(fullPath)
+		if err != nil {
+			return nil, err
+		}
+		r, dir = f.Request, filepath.Dir(fullPath)
+		if input == "" {
+			input = r.URL
+		}
+	} else {
+		colRoot, err = collectionRoot(workspaceDir, collection)
+		if err != nil {
+			return nil, err
+		}
+		dir = colRoot
+	}
+
+	chain, err := settingsChain(colRoot, dir)
+	if err != nil {
+		return nil, err
+	}
+	scope, err := c.newScope(workspaceDir, collection, colRoot, dir, chain, r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return scope.Resolve(input), nil
+}
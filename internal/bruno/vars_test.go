@@ -0,0 +1,83 @@
+package bruno
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestResolveVariables_Precedence(t *testing.T) {
+	c, ws := newTestCollection(t)
+	col := filepath.Join(ws, "api")
+	writeFile(t, filepath.Join(col, ".env"), "SECRET=dotenv\n")
+	writeFile(t, filepath.Join(col, "collection.bru"), "vars:pre-request {\n  a: collection\n  b: collection\n  c: collection\n  d: collection\n}\n")
+	writeFile(t, filepath.Join(col, "users", "folder.bru"), "vars:pre-request {\n  a: outer\n  b: outer\n  c: outer\n}\n")
+	writeFile(t, filepath.Join(col, "users", "admin", "folder.bru"), "vars:pre-request {\n  a: inner\n  b: inner\n}\n")
+	writeFile(t, filepath.Join(col, "users", "admin", "get.bru"), "get {\n  url: {{host}}/{{a}}/{{b}}/{{c}}/{{d}}/{{e}}\n}\n\nvars:pre-request {\n  a: request\n  host: {{scheme}}://example.com\n}\n")
+	if _, err := c.CreateEnvironment(ws, "api", "dev", []EnvVar{{Name: "d", Value: "env"}, {Name: "e", Value: "env"}, {Name: "scheme", Value: "https"}}, false); err != nil {
+		t.Fatalf("CreateEnvironment: %v", err)
+	}
+
+	res, err := c.ResolveVariables(ws, "api", "users/admin/get", "", RunOptions{Environment: "dev", Variables: map[string]string{"e": "runtime"}})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if want := "https://example.com/request/inner/outer/collection/runtime"; res.Value != want {
+		t.Fatalf("expected %q got %q", want, res.Value)
+	}
+
+	sources := map[string]string{}
+	for _, v := range res.Variables {
+		sources[v.Name] = v.Source + ":" + v.Folder
+	}
+	want := map[string]string{
+		"host":   "request:",
+		"scheme": "environment:",
+		"a":      "request:",
+		"b":      "folder:users/admin",
+		"c":      "folder:users",
+		"d":      "collection:",
+		"e":      "runtime:",
+	}
+	for k, v := range want {
+		if sources[k] != v {
+			t.Fatalf("expected %s from %q got %q", k, v, sources[k])
+		}
+	}
+
+	res, err = c.ResolveVariables(ws, "api", "", "{{process.env.SECRET}} {{missing}} {{d}}", RunOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error got %v", err)
+	}
+	if res.Value != "dotenv {{missing}} collection" {
+		t.Fatalf("unexpected value %q", res.Value)
+	}
+	if v := res.Variables[1]; v.Name != "missing" || v.Resolved {
+		t.Fatalf("expected unresolved variable got %+v", v)
+	}
+}
+
+func TestScope_DynamicAndCycles(t *testing.T) {
+	s := &Scope{}
+	s.add(SourceRuntime, "", map[string]string{"loop": "{{loop}}"})
+
+	uuidRe := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if v := s.Interpolate("{{$randomUUID}}"); !uuidRe.MatchString(v) {
+		t.Fatalf("expected uuid got %q", v)
+	}
+	ts, err := strconv.ParseInt(s.Interpolate("{{$timestamp}}"), 10, 64)
+	if err != nil || time.Since(time.Unix(ts, 0)) > time.Minute {
+		t.Fatalf("unexpected timestamp %d (%v)", ts, err)
+	}
+	if _, err := time.Parse(time.RFC3339, s.Interpolate("{{$isoTimestamp}}")); err != nil {
+		t.Fatalf("unexpected iso timestamp: %v", err)
+	}
+	if v := s.Interpolate("{{$unknown}}"); v != "{{$unknown}}" {
+		t.Fatalf("expected unknown helper to stay, got %q", v)
+	}
+	if v := s.Interpolate("{{loop}}"); v != "{{loop}}" {
+		t.Fatalf("expected cycle to terminate, got %q", v)
+	}
+}
@@ -0,0 +1,324 @@
+// Package history keeps the results of past runs in the workspace, one JSON
+// file per run under .bruno-mcp/runs.
+package history
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+	"github.com/Mayank2930/bruno-mcp-server/internal/report"
+)
+
+var (
+	ErrNotFound  = errors.New("run not found")
+	ErrInvalidID = errors.New("invalid run id")
+)
+
+const (
+	// Dir is where runs are kept, relative to the workspace.
+	Dir = ".bruno-mcp/runs"
+
+	// MaxRuns bounds the store; the oldest runs are pruned on save.
+	MaxRuns = 200
+
+	// maxBody bounds the response body kept per request.
+	maxBody = 64 << 10
+)
+
+// Kinds of run, named after the tool that produced them.
+const (
+	KindRequest    = "request"
+	KindCollection = "collection"
+	KindIteration  = "iteration"
+	KindCLI        = "cli"
+)
+
+// Entry is one stored run. Target is the request path or folder that ran.
+type Entry struct {
+	ID          string           `json:"id"`
+	Kind        string           `json:"kind"`
+	Collection  string           `json:"collection"`
+	Target      string           `json:"target,omitempty"`
+	Environment string           `json:"environment,omitempty"`
+	StartedAt   time.Time        `json:"startedAt"`
+	DurationMs  int64            `json:"durationMs"`
+	Passed      bool             `json:"passed"`
+	Inputs      map[string]any   `json:"inputs,omitempty"`
+	Summary     bruno.RunSummary `json:"summary"`
+	Requests    []RequestRecord  `json:"requests"`
+}
+
+// RequestRecord is one executed request of a run. Iteration is 1-based and
+// only set for iteration runs.
+type RequestRecord struct {
+	Path       string                  `json:"path"`
+	Iteration  int                     `json:"iteration,omitempty"`
+	Request    *bruno.SentRequest      `json:"request,omitempty"`
+	Response   *ResponseSummary        `json:"response,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+	Assertions []bruno.AssertionResult `json:"assertions,omitempty"`
+	Variables  map[string]string       `json:"variables,omitempty"`
+}
+
+// ResponseSummary is a response as stored: the body is cut to a preview.
+type ResponseSummary struct {
+	Status        int                 `json:"status"`
+	StatusText    string              `json:"statusText"`
+	Headers       map[string][]string `json:"headers,omitempty"`
+	Size          int64               `json:"size"`
+	Body          string              `json:"body,omitempty"`
+	BodyEncoding  string              `json:"bodyEncoding,omitempty"`
+	BodyTruncated bool                `json:"bodyTruncated,omitempty"`
+	DurationMs    int64               `json:"durationMs"`
+}
+
+// Summary is the listing form of an entry.
+type Summary struct {
+	ID          string           `json:"id"`
+	Kind        string           `json:"kind"`
+	Collection  string           `json:"collection"`
+	Target      string           `json:"target,omitempty"`
+	Environment string           `json:"environment,omitempty"`
+	StartedAt   time.Time        `json:"startedAt"`
+	DurationMs  int64            `json:"durationMs"`
+	Passed      bool             `json:"passed"`
+	Summary     bruno.RunSummary `json:"summary"`
+}
+
+func (e *Entry) summary() Summary {
+	return Summary{
+		ID:          e.ID,
+		Kind:        e.Kind,
+		Collection:  e.Collection,
+		Target:      e.Target,
+		Environment: e.Environment,
+		StartedAt:   e.StartedAt,
+		DurationMs:  e.DurationMs,
+		Passed:      e.Passed,
+		Summary:     e.Summary,
+	}
+}
+
+// NewEntry builds an entry from a run. started is when the run began; inputs
+// are the arguments it was called with.
+func NewEntry(kind, target, environment string, inputs map[string]any, started time.Time, run *report.Run) *Entry {
+	e := &Entry{
+		Kind:        kind,
+		Collection:  run.Collection,
+		Target:      target,
+		Environment: environment,
+		StartedAt:   started.UTC(),
+		DurationMs:  time.Since(started).Milliseconds(),
+		Inputs:      inputs,
+		Requests:    []RequestRecord{},
+	}
+	for i, it := range run.Iterations {
+		for _, r := range it.Results {
+			e.Summary.Add(&r)
+			rec := RequestRecord{Path: r.Path, Error: r.Error}
+			if kind == KindIteration {
+				rec.Iteration = i + 1
+			}
+			if res := r.Response; res != nil {
+				req := res.Request
+				rec.Request = &req
+				rec.Response = responseSummary(res)
+				rec.Assertions = res.Assertions
+				rec.Variables = res.Variables
+			}
+			e.Requests = append(e.Requests, rec)
+		}
+	}
+	e.Passed = e.Summary.FailedRequests == 0 && e.Summary.ErrorRequests == 0
+	return e
+}
+
+func responseSummary(res *bruno.RunResult) *ResponseSummary {
+	s := &ResponseSummary{
+		Status:        res.Status,
+		StatusText:    res.StatusText,
+		Headers:       res.Headers,
+		Size:          res.Size,
+		Body:          res.Body,
+		BodyEncoding:  res.BodyEncoding,
+		BodyTruncated: res.Truncated,
+		DurationMs:    res.DurationMs,
+	}
+	if len(s.Body) > maxBody {
+		cut := maxBody
+		for cut > 0 && !utf8.RuneStart(s.Body[cut]) {
+			cut--
+		}
+		s.Body = s.Body[:cut]
+		s.BodyTruncated = true
+	}
+	return s
+}
+
+// Store reads and writes the runs of one workspace.
+type Store struct {
+	dir string
+}
+
+func NewStore(workspaceDir string) *Store {
+	return &Store{dir: filepath.Join(workspaceDir, filepath.FromSlash(Dir))}
+}
+
+// Save assigns e an ID, writes it and prunes the store down to MaxRuns.
+func (s *Store) Save(e *Entry) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create runs dir: %w", err)
+	}
+	e.ID = newID(e.StartedAt)
+
+	b, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode run: %w", err)
+	}
+	tmp := filepath.Join(s.dir, "."+e.ID+".tmp")
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return fmt.Errorf("write run: %w", err)
+	}
+	if err := os.Rename(tmp, s.path(e.ID)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write run: %w", err)
+	}
+	return s.prune()
+}
+
+// Filter narrows List. Zero fields match everything.
+type Filter struct {
+	Collection string
+	Target     string
+	Kind       string
+	Limit      int
+}
+
+// List returns the stored runs, newest first.
+func (s *Store) List(f Filter) ([]Summary, error) {
+	ids, err := s.ids()
+	if err != nil {
+		return nil, err
+	}
+	out := []Summary{}
+	for i := len(ids) - 1; i >= 0; i-- {
+		e, err := s.Get(ids[i])
+		if err != nil {
+			// A run deleted or corrupted underneath us is skipped.
+			continue
+		}
+		if (f.Collection != "" && e.Collection != f.Collection) ||
+			(f.Kind != "" && e.Kind != f.Kind) ||
+			(f.Target != "" && strings.TrimSuffix(e.Target, ".bru") != strings.TrimSuffix(f.Target, ".bru")) {
+			continue
+		}
+		out = append(out, e.summary())
+		if f.Limit > 0 && len(out) == f.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *Store) Get(id string) (*Entry, error) {
+	if !validID(id) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	b, err := os.ReadFile(s.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("read run: %w", err)
+	}
+	var e Entry
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, fmt.Errorf("decode run %q: %w", id, err)
+	}
+	return &e, nil
+}
+
+func (s *Store) Delete(id string) error {
+	if !validID(id) {
+		return fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	if err := os.Remove(s.path(id)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %q", ErrNotFound, id)
+		}
+		return fmt.Errorf("delete run: %w", err)
+	}
+	return nil
+}
+
+// Clear deletes every stored run and returns how many there were.
+func (s *Store) Clear() (int, error) {
+	ids, err := s.ids()
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+			return 0, fmt.Errorf("delete run: %w", err)
+		}
+	}
+	return len(ids), nil
+}
+
+func (s *Store) path(id string) string { return filepath.Join(s.dir, id+".json") }
+
+// ids lists the stored run IDs, oldest first.
+func (s *Store) ids() ([]string, error) {
+	ents, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read runs dir: %w", err)
+	}
+	var ids []string
+	for _, e := range ents {
+		id, ok := strings.CutSuffix(e.Name(), ".json")
+		if ok && !e.IsDir() && validID(id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *Store) prune() error {
+	ids, err := s.ids()
+	if err != nil {
+		return err
+	}
+	for len(ids) > MaxRuns {
+		if err := os.Remove(s.path(ids[0])); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("prune runs: %w", err)
+		}
+		ids = ids[1:]
+	}
+	return nil
+}
+
+// newID returns an ID that sorts by start time.
+func newID(t time.Time) string {
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	return t.UTC().Format("20060102T150405.000000000Z") + "-" + hex.EncodeToString(b[:])
+}
+
+var idRe = regexp.MustCompile(`^[0-9]{8}T[0-9]{6}\.[0-9]{9}Z-[0-9a-f]{8}$`)
+
+func validID(id string) bool { return idRe.MatchString(id) }
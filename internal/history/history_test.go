@@ -0,0 +1,125 @@
+package history
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+	"github.com/Mayank2930/bruno-mcp-server/internal/report"
+)
+
+func requestEntry(path string, status int, body string) *Entry {
+	res := &bruno.RunResult{
+		Request:    bruno.SentRequest{Method: "GET", URL: "http://example.test/" + path},
+		Status:     status,
+		Body:       body,
+		Assertions: []bruno.AssertionResult{{LHSExpr: "res.status", RHSExpr: "eq 200", Status: bruno.AssertPass}},
+	}
+	if status != 200 {
+		res.Assertions[0].Status = bruno.AssertFail
+	}
+	return NewEntry(KindRequest, path, "dev", map[string]any{"path": path}, time.Now(), report.FromRequest("api", path, res))
+}
+
+func TestStore(t *testing.T) {
+	ws := t.TempDir()
+	s := NewStore(ws)
+
+	if runs, err := s.List(Filter{}); err != nil || len(runs) != 0 {
+		t.Fatalf("expected empty store, got %v %v", runs, err)
+	}
+
+	first := requestEntry("users.bru", 200, "first")
+	second := requestEntry("users.bru", 500, "second")
+	other := requestEntry("orders.bru", 200, "")
+	for _, e := range []*Entry{first, second, other} {
+		if err := s.Save(e); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(ws, ".bruno-mcp", "runs", first.ID+".json")); err != nil {
+		t.Fatalf("expected run file: %v", err)
+	}
+
+	runs, err := s.List(Filter{Target: "users", Limit: 5})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != second.ID || runs[0].Passed || !runs[1].Passed {
+		t.Fatalf("expected newest users run first, got %+v", runs)
+	}
+	if runs, _ := s.List(Filter{Limit: 1}); len(runs) != 1 || runs[0].ID != other.ID {
+		t.Fatalf("unexpected limited list: %+v", runs)
+	}
+
+	got, err := s.Get(first.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Requests[0].Response.Body != "first" || got.Requests[0].Request.URL != "http://example.test/users.bru" || got.Environment != "dev" {
+		t.Fatalf("unexpected entry: %+v", got)
+	}
+
+	if err := s.Delete(first.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(first.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := s.Get("../../etc/passwd"); !errors.Is(err, ErrInvalidID) {
+		t.Fatalf("expected ErrInvalidID, got %v", err)
+	}
+	if n, err := s.Clear(); err != nil || n != 2 {
+		t.Fatalf("Clear = %d, %v", n, err)
+	}
+}
+
+func TestStorePrunes(t *testing.T) {
+	s := NewStore(t.TempDir())
+	var firstID string
+	for i := 0; i < MaxRuns+3; i++ {
+		e := requestEntry("ping.bru", 200, "")
+		if err := s.Save(e); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if i == 0 {
+			firstID = e.ID
+		}
+	}
+	ids, _ := s.ids()
+	if len(ids) != MaxRuns {
+		t.Fatalf("expected %d runs, got %d", MaxRuns, len(ids))
+	}
+	if _, err := s.Get(firstID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected oldest run pruned, got %v", err)
+	}
+}
+
+func TestResponseBodyIsCut(t *testing.T) {
+	e := requestEntry("big.bru", 200, strings.Repeat("é", maxBody))
+	r := e.Requests[0].Response
+	if !r.BodyTruncated || len(r.Body) > maxBody || !strings.HasSuffix(r.Body, "é") {
+		t.Fatalf("expected body cut on a rune boundary, got %d bytes", len(r.Body))
+	}
+}
+
+func TestNewEntryCountsResults(t *testing.T) {
+	// The summary is counted from the results, not copied from the iteration.
+	it := &bruno.CollectionRunResult{Collection: "api", Results: []bruno.RequestRunResult{
+		{Path: "a.bru", Response: &bruno.RunResult{Status: 200}},
+		{Path: "b.bru", Error: "connection refused"},
+	}}
+	e := NewEntry(KindCollection, "", "", nil, time.Now(), &report.Run{Collection: "api", Iterations: []*bruno.CollectionRunResult{it}})
+
+	var want bruno.RunSummary
+	for i := range it.Results {
+		want.Add(&it.Results[i])
+	}
+	if e.Summary != want || e.Summary.ErrorRequests != 1 || e.Passed {
+		t.Fatalf("unexpected summary: %+v", e.Summary)
+	}
+}
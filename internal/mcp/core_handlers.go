@@ -3,48 +3,105 @@ package mcp
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+	"github.com/Mayank2930/bruno-mcp-server/internal/history"
+	"github.com/Mayank2930/bruno-mcp-server/internal/report"
 	"github.com/Mayank2930/bruno-mcp-server/internal/workspace"
 )
 
 type InitializeParams struct {
-	ClientInfo map[string]any `json:"clientInfo,omitempty"`
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ClientCapabilities `json:"capabilities"`
+	ClientInfo      Implementation     `json:"clientInfo"`
 }
 
 type ToolCallParams struct {
 	Name      string         `json:"name"`
-	Tool      string         `json:"tool:omitempty"`
+	Tool      string         `json:"tool,omitempty"`
 	Arguments map[string]any `json:"arguments,omitempty"`
 }
 
 func (s *Server) RegisterCoreMethods() {
 	s.Handle("initialize", s.handleInitialize)
+	s.Handle("notifications/initialized", s.handleInitialized)
+	s.Handle("ping", s.handlePing)
 	s.Handle("tools/list", s.handleToolList)
 	s.Handle("tools/call", s.handleToolsCall)
+	s.Handle("resources/list", s.handleResourcesList)
+	s.Handle("resources/templates/list", s.handleResourceTemplatesList)
+	s.Handle("resources/read", s.handleResourcesRead)
+	s.Handle("resources/subscribe", s.handleResourcesSubscribe)
+	s.Handle("resources/unsubscribe", s.handleResourcesUnsubscribe)
+	s.Handle("prompts/list", s.handlePromptsList)
+	s.Handle("prompts/get", s.handlePromptsGet)
 }
 
+const serverInstructions = `Tools for Bruno API collections (.bru files) on disk.
+Register a directory with workspace.register first; every other tool addresses a workspace by that name and a collection inside it.
+Use requests.* and folders.* to read and edit requests, environments.* for environments, requests.run, requests.assert, collections.run and collections.iterate to execute them, and runs.* to look at earlier results.
+Workspaces, collections and .bru files are also bruno:// resources; subscribe to one to hear when it changes on disk.
+Prompts cover common workflows: write-tests, create-crud-folder, debug-request and document-collection.
+Secret values from .env files and process.env references are masked as **** in results.`
+
+// cliInfoWait bounds how long initialize waits for the bru probe.
+var cliInfoWait = 2 * time.Second
+
 func (s *Server) handleInitialize(ctx context.Context, req Request) (any, *RPCError) {
-	_, _ = decodeParamsOptional[InitializeParams](req)
+	params, rpcErr := decodeParamsOptional[InitializeParams](req)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	version, rpcErr := s.session.initialize(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	capabilities := map[string]any{
+		"tools": map[string]any{
+			"listChanged": false,
+		},
+		"resources": map[string]any{
+			"subscribe":   true,
+			"listChanged": true,
+		},
+		"prompts": map[string]any{
+			"listChanged": false,
+		},
+	}
+	// Probing bru runs subprocesses, so the handshake waits for it only
+	// briefly; bruno.info always has the result.
+	if info := s.bruno.CLIInfoWithin(cliInfoWait); info != nil {
+		capabilities["experimental"] = map[string]any{"bruno": info}
+	}
 
 	return map[string]any{
+		"protocolVersion": version,
 		"serverInfo": map[string]any{
 			"name":    "mcp-server-bruno",
+			"title":   "Bruno",
 			"version": "0.1.0",
 		},
-		"capabilities": map[string]any{
-			"tools": map[string]any{
-				"list": true,
-				"call": true,
-			},
-		},
+		"capabilities": capabilities,
+		"instructions": serverInstructions,
 	}, nil
 }
 
+func (s *Server) handleInitialized(ctx context.Context, req Request) (any, *RPCError) {
+	s.session.initialized()
+	return nil, nil
+}
+
+func (s *Server) handlePing(ctx context.Context, req Request) (any, *RPCError) {
+	return map[string]any{}, nil
+}
+
 func (s *Server) handleToolList(ctx context.Context, req Request) (any, *RPCError) {
 	return map[string]any{
-		"tools": []map[string]any{
-			{
+		"tools": []any{
+			map[string]any{
 				"name":        "workspace.register",
 				"description": "Register a workspace by name (stub in Step 2)",
 				"inputSchema": map[string]any{
@@ -64,7 +121,7 @@ func (s *Server) handleToolList(ctx context.Context, req Request) (any, *RPCErro
 					},
 				},
 			},
-			{
+			map[string]any{
 				"name":        "workspace.get",
 				"description": "Get a registered workspace by name",
 				"inputSchema": map[string]any{
@@ -82,7 +139,7 @@ func (s *Server) handleToolList(ctx context.Context, req Request) (any, *RPCErro
 					},
 				},
 			},
-			{
+			map[string]any{
 				"name":        "workspace.list",
 				"description": "List registered workspaces",
 				"inputSchema": map[string]any{
@@ -105,7 +162,7 @@ func (s *Server) handleToolList(ctx context.Context, req Request) (any, *RPCErro
 					},
 				},
 			},
-			{
+			map[string]any{
 				"name":        "collections.list",
 				"description": "List collections in a workspace",
 				"inputSchema": map[string]any{
@@ -125,7 +182,7 @@ func (s *Server) handleToolList(ctx context.Context, req Request) (any, *RPCErro
 					},
 				},
 			},
-			{
+			map[string]any{
 				"name":        "requests.list",
 				"description": "List requests in a collection",
 				"inputSchema": map[string]any{
@@ -146,40 +203,308 @@ func (s *Server) handleToolList(ctx context.Context, req Request) (any, *RPCErro
 					},
 				},
 			},
-			{
-				"name":        "collections.create",
-				"description": "Create a Bruno collection (filesystem fallback)",
+			map[string]any{
+				"name":        "requests.get",
+				"description": "Get the parsed contents of a request: method, URL, headers, query, body, auth, vars, assertions, scripts and docs",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"path":       map[string]any{"type": "string"},
+					},
+					"required": []string{"workspace", "collection", "path"},
+				},
+				"outputSchema": requestSchema,
+			},
+			map[string]any{
+				"name":        "requests.run",
+				"description": "Send a request over HTTP and return status, headers, timing and body. Variables come from the environment, collection, folders and request, overridden by the given runtime variables; headers and auth are inherited from folder.bru and collection.bru.",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"path":        map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+						"variables":   variablesSchema,
+						"timeoutMs":   map[string]any{"type": "integer"},
+						"reports":     reportsSchema,
+					},
+					"required": []string{"workspace", "collection", "path"},
+				},
+				"outputSchema": withReportPaths(runResultSchema),
+			},
+			map[string]any{
+				"name":        "collections.run",
+				"description": "Run every request of a collection or folder in meta.seq order, recursing into folders like the Bruno runner. Runtime variables carry from one request to the next. Returns per-request responses and assertion outcomes with an aggregate summary.",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"folder":      map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+						"variables":   variablesSchema,
+						"bail":        map[string]any{"type": "boolean"},
+						"delayMs":     map[string]any{"type": "integer"},
+						"include":     stringListSchema,
+						"exclude":     stringListSchema,
+						"timeoutMs":   map[string]any{"type": "integer"},
+						"reports":     reportsSchema,
+					},
+					"required": []string{"workspace", "collection"},
+				},
+				"outputSchema": withReportPaths(collectionRunSchema),
+			},
+			map[string]any{
+				"name":        "collections.iterate",
+				"description": "Run a collection or folder once per row of an iteration data file (CSV with a header row, or a JSON array of objects) inside the workspace, binding each row's columns as variables",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"dataFile":    map[string]any{"type": "string"},
+						"folder":      map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+						"variables":   variablesSchema,
+						"bail":        map[string]any{"type": "boolean"},
+						"delayMs":     map[string]any{"type": "integer"},
+						"include":     stringListSchema,
+						"exclude":     stringListSchema,
+						"timeoutMs":   map[string]any{"type": "integer"},
+						"reports":     reportsSchema,
+					},
+					"required": []string{"workspace", "collection", "dataFile"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"collection": map[string]any{"type": "string"},
+						"folder":     map[string]any{"type": "string"},
+						"dataFile":   map[string]any{"type": "string"},
+						"iterations": map[string]any{
+							"type": "array",
+							"items": map[string]any{
+								"type": "object",
+								"properties": map[string]any{
+									"iteration": map[string]any{"type": "integer"},
+									"data":      variablesSchema,
+									"run":       collectionRunSchema,
+								},
+							},
+						},
+						"summary":    runSummarySchema,
+						"bailed":     map[string]any{"type": "boolean"},
+						"durationMs": map[string]any{"type": "integer"},
+						"reports":    stringListSchema,
+					},
+				},
+			},
+			map[string]any{
+				"name":        "bruno.info",
+				"description": "Report whether the bru CLI is installed, its version, and which commands and run flags it supports",
+				"inputSchema": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"available": map[string]any{"type": "boolean"},
+						"path":      map[string]any{"type": "string"},
+						"version":   map[string]any{"type": "string"},
+						"supported": map[string]any{"type": "boolean"},
+						"commands":  stringListSchema,
+						"runFlags":  stringListSchema,
+						"error":     map[string]any{"type": "string"},
+					},
+				},
+			},
+			map[string]any{
+				"name":        "bru.run",
+				"description": "Run a collection or folder with the bru CLI (`bru run`) so that scripts and tests execute in Bruno itself. Returns the parsed JSON report; requires bru on PATH.",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"folder":      map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+						"bail":        map[string]any{"type": "boolean"},
+						"reports":     reportsSchema,
+					},
+					"required": []string{"workspace", "collection"},
+				},
+				"outputSchema": withReportPaths(cliRunSchema),
+			},
+			map[string]any{
+				"name":        "runs.list",
+				"description": "List past request and collection runs of a workspace, newest first. Filter by collection, target (request path or folder) and kind (request, collection, iteration, cli).",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"target":     map[string]any{"type": "string"},
+						"kind":       map[string]any{"type": "string", "enum": []string{"request", "collection", "iteration", "cli"}},
+						"limit":      map[string]any{"type": "integer"},
+					},
+					"required": []string{"workspace"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"runs": map[string]any{"type": "array", "items": runSummaryEntrySchema},
+					},
+				},
+			},
+			map[string]any{
+				"name":        "runs.get",
+				"description": "Get a stored run: its inputs, the resolved requests, response summaries, assertions and timings",
 				"inputSchema": map[string]any{
 					"type": "object",
 					"properties": map[string]any{
 						"workspace": map[string]any{"type": "string"},
-						"name":      map[string]any{"type": "string"},
-						"overwrite": map[string]any{"type": "boolean"},
+						"id":        map[string]any{"type": "string"},
 					},
-					"required": []string{"workspace", "name"},
+					"required": []string{"workspace", "id"},
+				},
+				"outputSchema": runEntrySchema,
+			},
+			map[string]any{
+				"name":        "runs.delete",
+				"description": "Delete a stored run by id, or every stored run of the workspace with all: true",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace": map[string]any{"type": "string"},
+						"id":        map[string]any{"type": "string"},
+						"all":       map[string]any{"type": "boolean"},
+					},
+					"required": []string{"workspace"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"deleted": map[string]any{"type": "integer"},
+					},
+				},
+			},
+			map[string]any{
+				"name":        "requests.assert",
+				"description": "Send a request and check its assert block (or the given assertions, e.g. {\"key\": \"res.status\", \"value\": \"eq 200\"}) against the response. Reports pass/fail per assertion.",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"path":        map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+						"variables":   variablesSchema,
+						"assertions":  pairListSchema,
+						"timeoutMs":   map[string]any{"type": "integer"},
+						"reports":     reportsSchema,
+					},
+					"required": []string{"workspace", "collection", "path"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"passed":     map[string]any{"type": "boolean"},
+						"total":      map[string]any{"type": "integer"},
+						"failed":     map[string]any{"type": "integer"},
+						"status":     map[string]any{"type": "integer"},
+						"durationMs": map[string]any{"type": "integer"},
+						"assertions": assertionResultsSchema,
+						"reports":    stringListSchema,
+					},
+				},
+			},
+			map[string]any{
+				"name":        "variables.resolve",
+				"description": "Interpolate {{variables}} in a string the way a run would and report which layer (runtime, request, folder, collection, environment, process.env, dynamic) each variable came from. With a request path and no input, the request's URL is resolved.",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"path":        map[string]any{"type": "string"},
+						"input":       map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+						"variables":   variablesSchema,
+					},
+					"required": []string{"workspace", "collection"},
+				},
+				"outputSchema": resolutionSchema,
+			},
+			map[string]any{
+				"name":        "variables.runtime",
+				"description": "List the runtime variables that post-response vars set during this session for a collection, optionally clearing them",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"clear":      map[string]any{"type": "boolean"},
+					},
+					"required": []string{"workspace", "collection"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"variables": variablesSchema,
+					},
+				},
+			},
+			map[string]any{
+				"name":        "requests.update",
+				"description": "Apply a partial change to a request: set/remove headers, query, path params, vars and assertions; replace body or auth; change method, URL, name, seq, scripts, tests or docs. Untouched parts of the file are preserved.",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"path":       map[string]any{"type": "string"},
+						"patch":      requestPatchSchema,
+					},
+					"required": []string{"workspace", "collection", "path", "patch"},
+				},
+				"outputSchema": requestSchema,
+			},
+			map[string]any{
+				"name":        "requests.delete",
+				"description": "Delete a request file from a collection",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"path":       map[string]any{"type": "string"},
+					},
+					"required": []string{"workspace", "collection", "path"},
 				},
 				"outputSchema": map[string]any{
 					"type": "object",
 					"properties": map[string]any{
-						"name": map[string]any{"type": "string"},
 						"path": map[string]any{"type": "string"},
 					},
 				},
 			},
-			{
-				"name":        "requests.create",
-				"description": "Create a Bruno request file (filesystem fallback)",
+			map[string]any{
+				"name":        "requests.move",
+				"description": "Move or rename a request within its collection, updating meta.name and re-sequencing the source and destination folders",
 				"inputSchema": map[string]any{
 					"type": "object",
 					"properties": map[string]any{
 						"workspace":  map[string]any{"type": "string"},
 						"collection": map[string]any{"type": "string"},
 						"path":       map[string]any{"type": "string"},
-						"method":     map[string]any{"type": "string"},
-						"url":        map[string]any{"type": "string"},
+						"to":         map[string]any{"type": "string"},
 						"overwrite":  map[string]any{"type": "boolean"},
 					},
-					"required": []string{"workspace", "collection", "path", "method", "url"},
+					"required": []string{"workspace", "collection", "path", "to"},
 				},
 				"outputSchema": map[string]any{
 					"type": "object",
@@ -188,112 +513,1004 @@ func (s *Server) handleToolList(ctx context.Context, req Request) (any, *RPCErro
 					},
 				},
 			},
-		},
-	}, nil
-}
-
-func (s *Server) handleToolsCall(ctx context.Context, req Request) (any, *RPCError) {
-	params, rpcErr := decodeParams[ToolCallParams](req)
-	if rpcErr != nil {
-		return nil, rpcErr
-	}
-
-	toolName := params.Name
-	if toolName == "" {
-		toolName = params.Tool
-	}
-	if toolName == "" {
-		return nil, NewError(CodeInvalidParams, "Invalid params: name is required")
-	}
-
-	switch toolName {
-
-	case "workspace.register":
-		nameAny, ok := params.Arguments["name"]
-		if !ok {
-			return nil, NewError(CodeInvalidParams, "Invalid params: name is required")
-		}
-		pathAny, ok := params.Arguments["path"]
-		if !ok {
-			return nil, NewError(CodeInvalidParams, "Invalid params: path is required")
-		}
-
-		name, _ := nameAny.(string)
-		path, _ := pathAny.(string)
-
-		createIfMissing := false
-		if v, ok := params.Arguments["createIfMissing"]; ok {
-			if b, ok := v.(bool); ok {
-				createIfMissing = b
-			}
-		}
-
-		ws, err := s.registry.Register(name, path, createIfMissing)
-		if err != nil {
-			return nil, workspaceToRPCError(err)
-		}
-		return ws, nil
-
-	case "workspace.get":
-		nameAny, ok := params.Arguments["name"]
-		if !ok {
-			return nil, NewError(CodeInvalidParams, "Invalid params: name is required")
-		}
-		name, _ := nameAny.(string)
-
-		ws, err := s.registry.Get(name)
-		if err != nil {
-			return nil, workspaceToRPCError(err)
-		}
-		return ws, nil
-
-	case "workspace.list":
-		return map[string]any{
-			"workspaces": s.registry.List(),
-		}, nil
-
-	case "collections.list":
-		wsNameAny, ok := params.Arguments["workspace"]
-		if !ok {
-			return nil, NewError(CodeInvalidParams, "Invalid params: workspace is required")
-		}
-		wsName, _ := wsNameAny.(string)
-
-		ws, err := s.registry.Get(wsName)
-		if err != nil {
-			return nil, workspaceToRPCError(err)
-		}
-
-		cols, err := s.bruno.ListCollections(ws.Path)
-		if err != nil {
-			return nil, NewError(CodeInternalError, err.Error())
-		}
-
-		return map[string]any{"collections": cols}, nil
-
-	case "requests.list":
-		wsNameAny, ok := params.Arguments["workspace"]
-		if !ok {
-			return nil, NewError(CodeInvalidParams, "Invalid params: workspace is required")
-		}
-		colAny, ok := params.Arguments["collection"]
-		if !ok {
-			return nil, NewError(CodeInvalidParams, "Invalid params: collection is required")
+			map[string]any{
+				"name":        "requests.duplicate",
+				"description": "Copy a request to a new path; the copy is named after its file",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"path":       map[string]any{"type": "string"},
+						"to":         map[string]any{"type": "string"},
+						"overwrite":  map[string]any{"type": "boolean"},
+					},
+					"required": []string{"workspace", "collection", "path", "to"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"path": map[string]any{"type": "string"},
+					},
+				},
+			},
+			map[string]any{
+				"name":        "folders.create",
+				"description": "Create a folder in a collection with a folder.bru holding its name, seq and folder-level headers, auth, vars, scripts and docs",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"path":       map[string]any{"type": "string"},
+						"name":       map[string]any{"type": "string"},
+						"seq":        map[string]any{"type": "integer"},
+						"headers":    pairListSchema,
+						"auth":       authSchema,
+						"vars":       varsSchema,
+						"scripts":    scriptsSchema,
+						"tests":      map[string]any{"type": "string"},
+						"docs":       map[string]any{"type": "string"},
+					},
+					"required": []string{"workspace", "collection", "path"},
+				},
+				"outputSchema": folderSchema,
+			},
+			map[string]any{
+				"name":        "folders.list",
+				"description": "List folders in a collection",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+					},
+					"required": []string{"workspace", "collection"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"folders": map[string]any{
+							"type": "array",
+							"items": map[string]any{
+								"type": "object",
+								"properties": map[string]any{
+									"path": map[string]any{"type": "string"},
+									"name": map[string]any{"type": "string"},
+									"seq":  map[string]any{"type": "integer"},
+								},
+							},
+						},
+					},
+				},
+			},
+			map[string]any{
+				"name":        "folders.get",
+				"description": "Get a folder's folder.bru settings",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"path":       map[string]any{"type": "string"},
+					},
+					"required": []string{"workspace", "collection", "path"},
+				},
+				"outputSchema": folderSchema,
+			},
+			map[string]any{
+				"name":        "folders.update",
+				"description": "Apply a partial change to a folder's folder.bru, creating it if missing",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"path":       map[string]any{"type": "string"},
+						"patch":      settingsPatchSchema,
+					},
+					"required": []string{"workspace", "collection", "path", "patch"},
+				},
+				"outputSchema": folderSchema,
+			},
+			map[string]any{
+				"name":        "folders.delete",
+				"description": "Delete a folder; non-empty folders require recursive=true",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"path":       map[string]any{"type": "string"},
+						"recursive":  map[string]any{"type": "boolean"},
+					},
+					"required": []string{"workspace", "collection", "path"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"path": map[string]any{"type": "string"},
+					},
+				},
+			},
+			map[string]any{
+				"name":        "environments.list",
+				"description": "List environments of a collection",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+					},
+					"required": []string{"workspace", "collection"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"environments": stringListSchema,
+					},
+				},
+			},
+			map[string]any{
+				"name":        "environments.get",
+				"description": "Get the variables of an environment; secret variables are listed by name",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+					},
+					"required": []string{"workspace", "collection", "environment"},
+				},
+				"outputSchema": environmentSchema,
+			},
+			map[string]any{
+				"name":        "environments.create",
+				"description": "Create an environment file with variables; secret variables are stored by name only",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+						"variables":   envVarListSchema,
+						"overwrite":   map[string]any{"type": "boolean"},
+					},
+					"required": []string{"workspace", "collection", "environment"},
+				},
+				"outputSchema": environmentSchema,
+			},
+			map[string]any{
+				"name":        "environments.update",
+				"description": "Set or remove variables of an environment, optionally renaming it",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+						"rename":      map[string]any{"type": "string"},
+						"set":         envVarListSchema,
+						"remove":      stringListSchema,
+					},
+					"required": []string{"workspace", "collection", "environment"},
+				},
+				"outputSchema": environmentSchema,
+			},
+			map[string]any{
+				"name":        "environments.delete",
+				"description": "Delete an environment file",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":   map[string]any{"type": "string"},
+						"collection":  map[string]any{"type": "string"},
+						"environment": map[string]any{"type": "string"},
+					},
+					"required": []string{"workspace", "collection", "environment"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"environment": map[string]any{"type": "string"},
+					},
+				},
+			},
+			map[string]any{
+				"name":        "collections.create",
+				"description": "Create a Bruno collection (filesystem fallback)",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace": map[string]any{"type": "string"},
+						"name":      map[string]any{"type": "string"},
+						"overwrite": map[string]any{"type": "boolean"},
+					},
+					"required": []string{"workspace", "name"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"name": map[string]any{"type": "string"},
+						"path": map[string]any{"type": "string"},
+					},
+				},
+			},
+			map[string]any{
+				"name":        "collections.get",
+				"description": "Get collection-level settings from collection.bru (headers, auth, vars, scripts, docs) and the bruno.json config",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+					},
+					"required": []string{"workspace", "collection"},
+				},
+				"outputSchema": collectionSchema,
+			},
+			map[string]any{
+				"name":        "collections.update",
+				"description": "Update collection.bru settings with a partial patch and bruno.json (ignore, scripts.moduleWhitelist, presets, proxy, clientCertificates, ...) with a JSON merge patch",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"settings":   settingsPatchSchema,
+						"config":     map[string]any{"type": "object"},
+					},
+					"required": []string{"workspace", "collection"},
+				},
+				"outputSchema": collectionSchema,
+			},
+			map[string]any{
+				"name":        "requests.create",
+				"description": "Create a Bruno request file with optional headers, query and path params, body and auth (filesystem fallback)",
+				"inputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"workspace":  map[string]any{"type": "string"},
+						"collection": map[string]any{"type": "string"},
+						"path":       map[string]any{"type": "string"},
+						"method":     map[string]any{"type": "string"},
+						"url":        map[string]any{"type": "string"},
+						"overwrite":  map[string]any{"type": "boolean"},
+						"seq":        map[string]any{"type": "integer"},
+						"headers":    pairListSchema,
+						"query":      pairListSchema,
+						"pathParams": pairListSchema,
+						"body":       bodySchema,
+						"auth":       authSchema,
+					},
+					"required": []string{"workspace", "collection", "path", "method", "url"},
+				},
+				"outputSchema": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"path": map[string]any{"type": "string"},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (s *Server) handleToolsCall(ctx context.Context, req Request) (any, *RPCError) {
+	params, rpcErr := decodeParams[ToolCallParams](req)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	toolName := params.Name
+	if toolName == "" {
+		toolName = params.Tool
+	}
+	if toolName == "" {
+		return nil, NewError(CodeInvalidParams, "Invalid params: name is required")
+	}
+
+	res, rpcErr := s.invokeTool(ctx, toolName, params)
+	if rpcErr != nil && rpcErr.Code == CodeMethodNotFound {
+		return nil, rpcErr
+	}
+	out, rpcErr := newToolResult(res, rpcErr)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return out, nil
+}
+
+// invokeTool runs a tool and masks secrets in whatever it returns.
+func (s *Server) invokeTool(ctx context.Context, toolName string, params ToolCallParams) (any, *RPCError) {
+	res, rpcErr := s.callTool(ctx, toolName, params)
+	s.collectSecrets(params.Arguments)
+	if rpcErr != nil {
+		return nil, s.redactError(rpcErr)
+	}
+	return s.redactResult(res), nil
+}
+
+func (s *Server) callTool(ctx context.Context, toolName string, params ToolCallParams) (any, *RPCError) {
+	switch toolName {
+
+	case "workspace.register":
+		nameAny, ok := params.Arguments["name"]
+		if !ok {
+			return nil, NewError(CodeInvalidParams, "Invalid params: name is required")
+		}
+		pathAny, ok := params.Arguments["path"]
+		if !ok {
+			return nil, NewError(CodeInvalidParams, "Invalid params: path is required")
+		}
+
+		name, _ := nameAny.(string)
+		path, _ := pathAny.(string)
+
+		createIfMissing := false
+		if v, ok := params.Arguments["createIfMissing"]; ok {
+			if b, ok := v.(bool); ok {
+				createIfMissing = b
+			}
+		}
+
+		ws, err := s.registry.Register(name, path, createIfMissing)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+		return ws, nil
+
+	case "workspace.get":
+		nameAny, ok := params.Arguments["name"]
+		if !ok {
+			return nil, NewError(CodeInvalidParams, "Invalid params: name is required")
+		}
+		name, _ := nameAny.(string)
+
+		ws, err := s.registry.Get(name)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+		return ws, nil
+
+	case "workspace.list":
+		return map[string]any{
+			"workspaces": s.registry.List(),
+		}, nil
+
+	case "collections.list":
+		wsNameAny, ok := params.Arguments["workspace"]
+		if !ok {
+			return nil, NewError(CodeInvalidParams, "Invalid params: workspace is required")
+		}
+		wsName, _ := wsNameAny.(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		cols, err := s.bruno.ListCollections(ws.Path)
+		if err != nil {
+			return nil, NewError(CodeInternalError, err.Error())
+		}
+
+		return map[string]any{"collections": cols}, nil
+
+	case "requests.list":
+		wsNameAny, ok := params.Arguments["workspace"]
+		if !ok {
+			return nil, NewError(CodeInvalidParams, "Invalid params: workspace is required")
+		}
+		colAny, ok := params.Arguments["collection"]
+		if !ok {
+			return nil, NewError(CodeInvalidParams, "Invalid params: collection is required")
+		}
+		wsName, _ := wsNameAny.(string)
+		collection, _ := colAny.(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		reqs, err := s.bruno.ListRequests(ws.Path, collection)
+		if err != nil {
+			return nil, NewError(CodeInternalError, err.Error())
+		}
+
+		return map[string]any{"requests": reqs}, nil
+
+	case "requests.get":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relPath, _ := params.Arguments["path"].(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		r, err := s.bruno.GetRequest(ws.Path, col, relPath)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return r, nil
+
+	case "requests.run":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relPath, _ := params.Arguments["path"].(string)
+
+		opts, rpcErr := runOptions(params.Arguments)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+		targets, rpcErr := reportTargets(params.Arguments, ws.Path)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		opts.Variables = s.runtime.merged(wsName, col, opts.Variables)
+		started := time.Now()
+		res, err := s.bruno.RunRequest(ctx, ws.Path, col, relPath, opts)
+		if err != nil {
+			s.recordFailedRun(ws.Path, col, relPath, opts, params.Arguments, started, err)
+			return nil, brunoToRPCError(err)
+		}
+		s.runtime.set(wsName, col, res.Variables)
+
+		run := report.FromRequest(col, relPath, res)
+		s.recordRun(ws.Path, history.NewEntry(history.KindRequest, relPath, opts.Environment, params.Arguments, started, run))
+
+		return s.writeReports(ws.Path, params.Arguments, targets, run, res)
+
+	case "collections.run":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+
+		opts, rpcErr := collectionRunOptions(params.Arguments)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+		targets, rpcErr := reportTargets(params.Arguments, ws.Path)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		opts.Variables = s.runtime.merged(wsName, col, opts.Variables)
+		started := time.Now()
+		res, err := s.bruno.RunCollection(ctx, ws.Path, col, opts)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+		s.runtime.set(wsName, col, res.Variables)
+
+		run := report.FromCollection(res)
+		s.recordRun(ws.Path, history.NewEntry(history.KindCollection, opts.Folder, opts.Environment, params.Arguments, started, run))
+
+		return s.writeReports(ws.Path, params.Arguments, targets, run, res)
+
+	case "collections.iterate":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		dataFile, _ := params.Arguments["dataFile"].(string)
+
+		opts, rpcErr := collectionRunOptions(params.Arguments)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+		targets, rpcErr := reportTargets(params.Arguments, ws.Path)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		opts.Variables = s.runtime.merged(wsName, col, opts.Variables)
+		started := time.Now()
+		res, err := s.bruno.IterateCollection(ctx, ws.Path, col, dataFile, opts)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		run := report.FromIterations(res)
+		s.recordRun(ws.Path, history.NewEntry(history.KindIteration, opts.Folder, opts.Environment, params.Arguments, started, run))
+
+		return s.writeReports(ws.Path, params.Arguments, targets, run, res)
+
+	case "bruno.info":
+		return s.bruno.CLIInfo(), nil
+
+	case "bru.run":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+
+		var opts bruno.CLIRunOptions
+		opts.Environment, _ = params.Arguments["environment"].(string)
+		opts.Folder, _ = params.Arguments["folder"].(string)
+		opts.Bail, _ = params.Arguments["bail"].(bool)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+		targets, rpcErr := reportTargets(params.Arguments, ws.Path)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		started := time.Now()
+		res, err := s.bruno.RunWithCLI(ctx, ws.Path, col, opts)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		run := report.FromCLIRun(res)
+		s.recordRun(ws.Path, history.NewEntry(history.KindCLI, opts.Folder, opts.Environment, params.Arguments, started, run))
+
+		return s.writeReports(ws.Path, params.Arguments, targets, run, res)
+
+	case "runs.list":
+		wsName, _ := params.Arguments["workspace"].(string)
+
+		var f history.Filter
+		f.Collection, _ = params.Arguments["collection"].(string)
+		f.Target, _ = params.Arguments["target"].(string)
+		f.Kind, _ = params.Arguments["kind"].(string)
+		if v, ok := params.Arguments["limit"].(float64); ok {
+			if v < 0 {
+				return nil, NewError(CodeInvalidParams, "Invalid params: limit must not be negative")
+			}
+			f.Limit = int(v)
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		runs, err := history.NewStore(ws.Path).List(f)
+		if err != nil {
+			return nil, historyToRPCError(err)
+		}
+
+		return map[string]any{"runs": runs}, nil
+
+	case "runs.get":
+		wsName, _ := params.Arguments["workspace"].(string)
+		id, _ := params.Arguments["id"].(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		e, err := history.NewStore(ws.Path).Get(id)
+		if err != nil {
+			return nil, historyToRPCError(err)
+		}
+
+		return e, nil
+
+	case "runs.delete":
+		wsName, _ := params.Arguments["workspace"].(string)
+		id, _ := params.Arguments["id"].(string)
+		all, _ := params.Arguments["all"].(bool)
+		if (id == "") == !all {
+			return nil, NewError(CodeInvalidParams, "Invalid params: exactly one of id or all is required")
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		store := history.NewStore(ws.Path)
+		if all {
+			n, err := store.Clear()
+			if err != nil {
+				return nil, historyToRPCError(err)
+			}
+			return map[string]any{"deleted": n}, nil
+		}
+		if err := store.Delete(id); err != nil {
+			return nil, historyToRPCError(err)
+		}
+
+		return map[string]any{"deleted": 1}, nil
+
+	case "requests.assert":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relPath, _ := params.Arguments["path"].(string)
+
+		opts, rpcErr := runOptions(params.Arguments)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		if opts.Assertions, rpcErr = decodeArg[[]bruno.Pair](params.Arguments, "assertions"); rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+		targets, rpcErr := reportTargets(params.Arguments, ws.Path)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		opts.Variables = s.runtime.merged(wsName, col, opts.Variables)
+		started := time.Now()
+		res, err := s.bruno.RunRequest(ctx, ws.Path, col, relPath, opts)
+		if err != nil {
+			s.recordFailedRun(ws.Path, col, relPath, opts, params.Arguments, started, err)
+			return nil, brunoToRPCError(err)
+		}
+		s.runtime.set(wsName, col, res.Variables)
+
+		failed := 0
+		for _, a := range res.Assertions {
+			if !a.Passed() {
+				failed++
+			}
+		}
+		run := report.FromRequest(col, relPath, res)
+		s.recordRun(ws.Path, history.NewEntry(history.KindRequest, relPath, opts.Environment, params.Arguments, started, run))
+
+		return s.writeReports(ws.Path, params.Arguments, targets, run, map[string]any{
+			"passed":     failed == 0,
+			"total":      len(res.Assertions),
+			"failed":     failed,
+			"status":     res.Status,
+			"durationMs": res.DurationMs,
+			"assertions": res.Assertions,
+		})
+
+	case "variables.resolve":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relPath, _ := params.Arguments["path"].(string)
+		input, _ := params.Arguments["input"].(string)
+		if relPath == "" && input == "" {
+			return nil, NewError(CodeInvalidParams, "Invalid params: input or path is required")
+		}
+
+		opts, rpcErr := runOptions(params.Arguments)
+		if rpcErr != nil {
+			return nil, rpcErr
 		}
-		wsName, _ := wsNameAny.(string)
-		collection, _ := colAny.(string)
 
 		ws, err := s.registry.Get(wsName)
 		if err != nil {
 			return nil, workspaceToRPCError(err)
 		}
 
-		reqs, err := s.bruno.ListRequests(ws.Path, collection)
+		opts.Variables = s.runtime.merged(wsName, col, opts.Variables)
+		res, err := s.bruno.ResolveVariables(ws.Path, col, relPath, input, opts)
 		if err != nil {
-			return nil, NewError(CodeInternalError, err.Error())
+			return nil, brunoToRPCError(err)
 		}
 
-		return map[string]any{"requests": reqs}, nil
+		return res, nil
+
+	case "variables.runtime":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		reset, _ := params.Arguments["clear"].(bool)
+
+		if _, err := s.registry.Get(wsName); err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		vars := s.runtime.get(wsName, col)
+		if reset {
+			s.runtime.clear(wsName, col)
+		}
+
+		return map[string]any{"variables": vars}, nil
+
+	case "requests.update":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relPath, _ := params.Arguments["path"].(string)
+
+		if _, ok := params.Arguments["patch"]; !ok {
+			return nil, NewError(CodeInvalidParams, "Invalid params: patch is required")
+		}
+		patch, rpcErr := decodeArg[bruno.RequestPatch](params.Arguments, "patch")
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		r, err := s.bruno.UpdateRequest(ws.Path, col, relPath, patch)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return r, nil
+
+	case "requests.delete":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relPath, _ := params.Arguments["path"].(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		deleted, err := s.bruno.DeleteRequest(ws.Path, col, relPath)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return map[string]any{"path": deleted}, nil
+
+	case "requests.move", "requests.duplicate":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relPath, _ := params.Arguments["path"].(string)
+		to, _ := params.Arguments["to"].(string)
+
+		opts := bruno.MoveRequestOptions{}
+		if v, ok := params.Arguments["overwrite"].(bool); ok {
+			opts.Overwrite = v
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		op := s.bruno.MoveRequest
+		if toolName == "requests.duplicate" {
+			op = s.bruno.DuplicateRequest
+		}
+		newPath, err := op(ws.Path, col, relPath, to, opts)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return map[string]any{"path": newPath}, nil
+
+	case "folders.create":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relDir, _ := params.Arguments["path"].(string)
+
+		var opts bruno.CreateFolderOptions
+		opts.Settings.Meta.Name, _ = params.Arguments["name"].(string)
+		if v, ok := params.Arguments["seq"].(float64); ok {
+			opts.Settings.Meta.Seq = int(v)
+		}
+		opts.Settings.Tests, _ = params.Arguments["tests"].(string)
+		opts.Settings.Docs, _ = params.Arguments["docs"].(string)
+
+		var rpcErr *RPCError
+		if opts.Settings.Headers, rpcErr = decodeArg[[]bruno.Pair](params.Arguments, "headers"); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if opts.Settings.Auth, rpcErr = decodeArg[bruno.Auth](params.Arguments, "auth"); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if opts.Settings.Vars, rpcErr = decodeArg[bruno.Vars](params.Arguments, "vars"); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if opts.Settings.Scripts, rpcErr = decodeArg[bruno.Scripts](params.Arguments, "scripts"); rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		f, err := s.bruno.CreateFolder(ws.Path, col, relDir, opts)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return f, nil
+
+	case "folders.list":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		folders, err := s.bruno.ListFolders(ws.Path, col)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return map[string]any{"folders": folders}, nil
+
+	case "folders.get":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relDir, _ := params.Arguments["path"].(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		f, err := s.bruno.GetFolder(ws.Path, col, relDir)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return f, nil
+
+	case "folders.update":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relDir, _ := params.Arguments["path"].(string)
+
+		if _, ok := params.Arguments["patch"]; !ok {
+			return nil, NewError(CodeInvalidParams, "Invalid params: patch is required")
+		}
+		patch, rpcErr := decodeArg[bruno.SettingsPatch](params.Arguments, "patch")
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		f, err := s.bruno.UpdateFolder(ws.Path, col, relDir, patch)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return f, nil
+
+	case "folders.delete":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		relDir, _ := params.Arguments["path"].(string)
+		recursive, _ := params.Arguments["recursive"].(bool)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		deleted, err := s.bruno.DeleteFolder(ws.Path, col, relDir, recursive)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return map[string]any{"path": deleted}, nil
+
+	case "environments.list":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		envs, err := s.bruno.ListEnvironments(ws.Path, col)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return map[string]any{"environments": envs}, nil
+
+	case "environments.get":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		env, _ := params.Arguments["environment"].(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		e, err := s.bruno.GetEnvironment(ws.Path, col, env)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return e, nil
+
+	case "environments.create":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		env, _ := params.Arguments["environment"].(string)
+		overwrite, _ := params.Arguments["overwrite"].(bool)
+
+		vars, rpcErr := decodeArg[[]bruno.EnvVar](params.Arguments, "variables")
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		e, err := s.bruno.CreateEnvironment(ws.Path, col, env, vars, overwrite)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return e, nil
+
+	case "environments.update":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		env, _ := params.Arguments["environment"].(string)
+
+		var patch bruno.EnvironmentPatch
+		if v, ok := params.Arguments["rename"].(string); ok {
+			patch.Rename = &v
+		}
+		var rpcErr *RPCError
+		if patch.Set, rpcErr = decodeArg[[]bruno.EnvVar](params.Arguments, "set"); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if patch.Remove, rpcErr = decodeArg[[]string](params.Arguments, "remove"); rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		e, err := s.bruno.UpdateEnvironment(ws.Path, col, env, patch)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return e, nil
+
+	case "environments.delete":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+		env, _ := params.Arguments["environment"].(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		if err := s.bruno.DeleteEnvironment(ws.Path, col, env); err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return map[string]any{"environment": env}, nil
 
 	case "collections.create":
 		wsName, _ := params.Arguments["workspace"].(string)
@@ -316,6 +1533,47 @@ func (s *Server) handleToolsCall(ctx context.Context, req Request) (any, *RPCErr
 
 		return map[string]any{"name": name, "path": dir}, nil
 
+	case "collections.get":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		c, err := s.bruno.GetCollection(ws.Path, col)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return c, nil
+
+	case "collections.update":
+		wsName, _ := params.Arguments["workspace"].(string)
+		col, _ := params.Arguments["collection"].(string)
+
+		var patch bruno.CollectionPatch
+		var rpcErr *RPCError
+		if patch.Settings, rpcErr = decodeArg[*bruno.SettingsPatch](params.Arguments, "settings"); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if patch.Config, rpcErr = decodeArg[map[string]any](params.Arguments, "config"); rpcErr != nil {
+			return nil, rpcErr
+		}
+
+		ws, err := s.registry.Get(wsName)
+		if err != nil {
+			return nil, workspaceToRPCError(err)
+		}
+
+		c, err := s.bruno.UpdateCollection(ws.Path, col, patch)
+		if err != nil {
+			return nil, brunoToRPCError(err)
+		}
+
+		return c, nil
+
 	case "requests.create":
 		wsName, _ := params.Arguments["workspace"].(string)
 		col, _ := params.Arguments["collection"].(string)
@@ -323,9 +1581,29 @@ func (s *Server) handleToolsCall(ctx context.Context, req Request) (any, *RPCErr
 		method, _ := params.Arguments["method"].(string)
 		url, _ := params.Arguments["url"].(string)
 
-		overwrite := false
+		opts := bruno.CreateRequestOptions{}
 		if v, ok := params.Arguments["overwrite"].(bool); ok {
-			overwrite = v
+			opts.Overwrite = v
+		}
+		if v, ok := params.Arguments["seq"].(float64); ok {
+			opts.Seq = int(v)
+		}
+
+		var rpcErr *RPCError
+		if opts.Headers, rpcErr = decodeArg[[]bruno.Pair](params.Arguments, "headers"); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if opts.Query, rpcErr = decodeArg[[]bruno.Pair](params.Arguments, "query"); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if opts.PathParams, rpcErr = decodeArg[[]bruno.Pair](params.Arguments, "pathParams"); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if opts.Body, rpcErr = decodeArg[bruno.Body](params.Arguments, "body"); rpcErr != nil {
+			return nil, rpcErr
+		}
+		if opts.Auth, rpcErr = decodeArg[bruno.Auth](params.Arguments, "auth"); rpcErr != nil {
+			return nil, rpcErr
 		}
 
 		ws, err := s.registry.Get(wsName)
@@ -333,9 +1611,9 @@ func (s *Server) handleToolsCall(ctx context.Context, req Request) (any, *RPCErr
 			return nil, workspaceToRPCError(err)
 		}
 
-		created, err := s.bruno.CreateRequest(ws.Path, col, relPath, method, url, bruno.CreateRequestOptions{Overwrite: overwrite})
+		created, err := s.bruno.CreateRequest(ws.Path, col, relPath, method, url, opts)
 		if err != nil {
-			return nil, NewError(CodeInternalError, err.Error())
+			return nil, brunoToRPCError(err)
 		}
 
 		return map[string]any{"path": created}, nil
@@ -356,3 +1634,30 @@ func workspaceToRPCError(err error) *RPCError {
 		return NewError(CodeInternalError, err.Error())
 	}
 }
+
+func brunoToRPCError(err error) *RPCError {
+	switch {
+	case errors.Is(err, bruno.ErrInvalidCollectionName),
+		errors.Is(err, bruno.ErrInvalidRequestPath),
+		errors.Is(err, bruno.ErrInvalidFolderPath),
+		errors.Is(err, bruno.ErrFolderNotEmpty),
+		errors.Is(err, bruno.ErrInvalidBru),
+		errors.Is(err, bruno.ErrInvalidConfig),
+		errors.Is(err, bruno.ErrInvalidEnvironment),
+		errors.Is(err, bruno.ErrUnsupportedAuth),
+		errors.Is(err, bruno.ErrInvalidDataFile),
+		errors.Is(err, bruno.ErrAlreadyExists),
+		errors.Is(err, bruno.ErrCollectionMissing),
+		errors.Is(err, bruno.ErrNotACollection),
+		errors.Is(err, bruno.ErrRequestNotFound),
+		errors.Is(err, bruno.ErrFolderNotFound),
+		errors.Is(err, bruno.ErrEnvironmentNotFound):
+		return NewError(CodeInvalidParams, err.Error())
+	case errors.Is(err, bruno.ErrBruNotFound):
+		return NewError(CodeInternalError, err.Error()+": install it with `npm install -g @usebruno/cli` and make sure bru is on PATH")
+	case errors.Is(err, bruno.ErrUnsupportedCLI):
+		return NewError(CodeInternalError, err.Error()+": upgrade it with `npm install -g @usebruno/cli@latest`")
+	default:
+		return NewError(CodeInternalError, err.Error())
+	}
+}
@@ -6,6 +6,8 @@ const (
 	CodeMethodNotFound = -32601
 	CodeInvalidParams  = -32602
 	CodeInternalError  = -32603
+
+	CodeResourceNotFound = -32002
 )
 
 func NewError(code int, message string) *RPCError {
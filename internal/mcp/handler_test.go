@@ -2,8 +2,21 @@ package mcp
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+	"github.com/Mayank2930/bruno-mcp-server/internal/history"
 )
 
 func TestDispatch_MethodNotFound(t *testing.T) {
@@ -99,3 +112,951 @@ func TestToolsCall_UnknownTool(t *testing.T) {
 		t.Fatalf("expected CodeMethodNotFound got %d", rpcErr.Code)
 	}
 }
+
+// callTool invokes a tool below the tools/call result wrapping, so that tests
+// see the tool's own result and error code. Arguments go through JSON as
+// they would on the wire.
+func callTool(t *testing.T, s *Server, name string, args map[string]any) (any, *RPCError) {
+	t.Helper()
+	b, err := json.Marshal(map[string]any{"name": name, "arguments": args})
+	if err != nil {
+		t.Fatalf("marshal tool params: %v", err)
+	}
+	var params ToolCallParams
+	if err := json.Unmarshal(b, &params); err != nil {
+		t.Fatalf("unmarshal tool params: %v", err)
+	}
+	return s.invokeTool(context.Background(), name, params)
+}
+
+func newWorkspaceServer(t *testing.T) *Server {
+	t.Helper()
+	s := NewServer()
+	s.RegisterCoreMethods()
+
+	if _, rpcErr := callTool(t, s, "workspace.register", map[string]any{"name": "ws", "path": t.TempDir()}); rpcErr != nil {
+		t.Fatalf("workspace.register: %+v", rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "collections.create", map[string]any{"workspace": "ws", "name": "api"}); rpcErr != nil {
+		t.Fatalf("collections.create: %+v", rpcErr)
+	}
+	return s
+}
+
+func TestToolsCall_RequestsGet(t *testing.T) {
+	s := newWorkspaceServer(t)
+
+	_, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "users/list", "method": "GET", "url": "https://example.com/users",
+	})
+	if rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "requests.get", map[string]any{"workspace": "ws", "collection": "api", "path": "users/list"})
+	if rpcErr != nil {
+		t.Fatalf("expected nil error got %+v", rpcErr)
+	}
+	r, ok := res.(*bruno.Request)
+	if !ok {
+		t.Fatalf("expected *bruno.Request, got %T", res)
+	}
+	if r.Method != "get" || r.URL != "https://example.com/users" || r.Meta.Name != "list" {
+		t.Fatalf("unexpected request: %+v", r)
+	}
+
+	_, rpcErr = callTool(t, s, "requests.get", map[string]any{"workspace": "ws", "collection": "api", "path": "missing"})
+	if rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for missing request, got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_RequestsUpdate(t *testing.T) {
+	s := newWorkspaceServer(t)
+
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "method": "get", "url": "https://example.com/ping",
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "requests.update", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping",
+		"patch": map[string]any{
+			"method":     "post",
+			"setHeaders": []any{map[string]any{"key": "X-Id", "value": "7"}},
+		},
+	})
+	if rpcErr != nil {
+		t.Fatalf("expected nil error got %+v", rpcErr)
+	}
+	r := res.(*bruno.Request)
+	if r.Method != "post" || len(r.Headers) != 1 || r.URL != "https://example.com/ping" {
+		t.Fatalf("unexpected request after update: %+v", r)
+	}
+
+	_, rpcErr = callTool(t, s, "requests.update", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping",
+		"patch": map[string]any{"setHeader": []any{}},
+	})
+	if rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for unknown patch field, got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_RequestsCreateRich(t *testing.T) {
+	s := newWorkspaceServer(t)
+
+	_, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "login", "method": "post", "url": "https://example.com/login",
+		"headers": []any{map[string]any{"key": "Accept", "value": "application/json"}},
+		"body":    map[string]any{"mode": "formUrlEncoded", "formUrlEncoded": []any{map[string]any{"key": "user", "value": "ada"}}},
+		"auth":    map[string]any{"mode": "bearer", "bearer": map[string]any{"token": "{{token}}"}},
+	})
+	if rpcErr != nil {
+		t.Fatalf("expected nil error got %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "requests.get", map[string]any{"workspace": "ws", "collection": "api", "path": "login"})
+	if rpcErr != nil {
+		t.Fatalf("requests.get: %+v", rpcErr)
+	}
+	r := res.(*bruno.Request)
+	if r.Body.Mode != "formUrlEncoded" || len(r.Body.FormURLEncoded) != 1 || r.Auth.Bearer == nil || len(r.Headers) != 1 {
+		t.Fatalf("unexpected request: %+v", r)
+	}
+
+	_, rpcErr = callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "bad", "method": "get", "url": "https://example.com",
+		"body": map[string]any{"mode": "yaml"},
+	})
+	if rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for bad body mode, got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_RequestsMoveDuplicateDelete(t *testing.T) {
+	s := newWorkspaceServer(t)
+
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "method": "get", "url": "https://example.com",
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "requests.move", map[string]any{"workspace": "ws", "collection": "api", "path": "ping", "to": "health/ping"})
+	if rpcErr != nil {
+		t.Fatalf("requests.move: %+v", rpcErr)
+	}
+	if p := res.(map[string]any)["path"]; p != "health/ping.bru" {
+		t.Fatalf("unexpected moved path %v", p)
+	}
+
+	if _, rpcErr := callTool(t, s, "requests.duplicate", map[string]any{"workspace": "ws", "collection": "api", "path": "health/ping", "to": "health/pong"}); rpcErr != nil {
+		t.Fatalf("requests.duplicate: %+v", rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "requests.delete", map[string]any{"workspace": "ws", "collection": "api", "path": "health/ping"}); rpcErr != nil {
+		t.Fatalf("requests.delete: %+v", rpcErr)
+	}
+
+	res, rpcErr = callTool(t, s, "requests.list", map[string]any{"workspace": "ws", "collection": "api"})
+	if rpcErr != nil {
+		t.Fatalf("requests.list: %+v", rpcErr)
+	}
+	reqs := res.(map[string]any)["requests"].([]string)
+	if len(reqs) != 1 || reqs[0] != "health/pong.bru" {
+		t.Fatalf("unexpected requests after lifecycle ops: %v", reqs)
+	}
+}
+
+func TestToolsCall_Folders(t *testing.T) {
+	s := newWorkspaceServer(t)
+
+	res, rpcErr := callTool(t, s, "folders.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "billing",
+		"auth": map[string]any{"mode": "basic", "basic": map[string]any{"username": "u", "password": "p"}},
+	})
+	if rpcErr != nil {
+		t.Fatalf("folders.create: %+v", rpcErr)
+	}
+	if f := res.(*bruno.Folder); f.Path != "billing" || f.Auth.Basic == nil {
+		t.Fatalf("unexpected folder: %+v", f)
+	}
+
+	if _, rpcErr := callTool(t, s, "folders.update", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "billing",
+		"patch": map[string]any{"docs": "Billing endpoints"},
+	}); rpcErr != nil {
+		t.Fatalf("folders.update: %+v", rpcErr)
+	}
+
+	res, rpcErr = callTool(t, s, "folders.get", map[string]any{"workspace": "ws", "collection": "api", "path": "billing"})
+	if rpcErr != nil {
+		t.Fatalf("folders.get: %+v", rpcErr)
+	}
+	if f := res.(*bruno.Folder); f.Docs != "Billing endpoints" || f.Meta.Name != "billing" {
+		t.Fatalf("unexpected folder after update: %+v", f)
+	}
+
+	res, rpcErr = callTool(t, s, "folders.list", map[string]any{"workspace": "ws", "collection": "api"})
+	if rpcErr != nil {
+		t.Fatalf("folders.list: %+v", rpcErr)
+	}
+	if fs := res.(map[string]any)["folders"].([]bruno.FolderInfo); len(fs) != 1 {
+		t.Fatalf("unexpected folders: %+v", fs)
+	}
+
+	if _, rpcErr := callTool(t, s, "folders.delete", map[string]any{"workspace": "ws", "collection": "api", "path": "billing"}); rpcErr != nil {
+		t.Fatalf("folders.delete: %+v", rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "folders.get", map[string]any{"workspace": "ws", "collection": "api", "path": "billing"}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for deleted folder, got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_FoldersSkipRunHistory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// The registered workspace is the collection itself, so the run history
+	// lands inside the collection.
+	dir := filepath.Join(t.TempDir(), "api")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bruno.json"), []byte(`{"version":"1","name":"api","type":"collection"}`), 0o644); err != nil {
+		t.Fatalf("write bruno.json: %v", err)
+	}
+	s := NewServer()
+	s.RegisterCoreMethods()
+	if _, rpcErr := callTool(t, s, "workspace.register", map[string]any{"name": "ws", "path": dir}); rpcErr != nil {
+		t.Fatalf("workspace.register: %+v", rpcErr)
+	}
+	target := map[string]any{"workspace": "ws", "collection": "api", "path": "ping"}
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "method": "get", "url": srv.URL,
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "requests.run", target); rpcErr != nil {
+		t.Fatalf("requests.run: %+v", rpcErr)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".bruno-mcp", "runs")); err != nil {
+		t.Fatalf("expected a recorded run: %v", err)
+	}
+
+	res, rpcErr := callTool(t, s, "folders.list", map[string]any{"workspace": "ws", "collection": "api"})
+	if rpcErr != nil {
+		t.Fatalf("folders.list: %+v", rpcErr)
+	}
+	if fs := res.(map[string]any)["folders"].([]bruno.FolderInfo); len(fs) != 0 {
+		t.Fatalf("expected no folders, got %+v", fs)
+	}
+	res, rpcErr = callTool(t, s, "folders.create", map[string]any{"workspace": "ws", "collection": "api", "path": "users"})
+	if rpcErr != nil {
+		t.Fatalf("folders.create: %+v", rpcErr)
+	}
+	if f := res.(*bruno.Folder); f.Meta.Seq != 1 {
+		t.Fatalf("expected the first folder seq, got %+v", f.Meta)
+	}
+}
+
+func TestToolsCall_CollectionsGetUpdate(t *testing.T) {
+	s := newWorkspaceServer(t)
+
+	_, rpcErr := callTool(t, s, "collections.update", map[string]any{
+		"workspace": "ws", "collection": "api",
+		"settings": map[string]any{"setPreRequestVars": []any{map[string]any{"key": "region", "value": "eu"}}},
+		"config":   map[string]any{"presets": map[string]any{"requestUrl": "https://api.example.com"}},
+	})
+	if rpcErr != nil {
+		t.Fatalf("collections.update: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "collections.get", map[string]any{"workspace": "ws", "collection": "api"})
+	if rpcErr != nil {
+		t.Fatalf("collections.get: %+v", rpcErr)
+	}
+	c := res.(*bruno.Collection)
+	if len(c.Settings.Vars.PreRequest) != 1 || c.Config["presets"] == nil {
+		t.Fatalf("unexpected collection: %+v", c)
+	}
+
+	_, rpcErr = callTool(t, s, "collections.update", map[string]any{
+		"workspace": "ws", "collection": "api", "config": map[string]any{"type": "folder"},
+	})
+	if rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for bad config, got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_Environments(t *testing.T) {
+	s := newWorkspaceServer(t)
+
+	if _, rpcErr := callTool(t, s, "environments.create", map[string]any{
+		"workspace": "ws", "collection": "api", "environment": "local",
+		"variables": []any{map[string]any{"name": "host", "value": "http://localhost"}, map[string]any{"name": "token", "secret": true}},
+	}); rpcErr != nil {
+		t.Fatalf("environments.create: %+v", rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "environments.update", map[string]any{
+		"workspace": "ws", "collection": "api", "environment": "local",
+		"set": []any{map[string]any{"name": "port", "value": "8080"}},
+	}); rpcErr != nil {
+		t.Fatalf("environments.update: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "environments.get", map[string]any{"workspace": "ws", "collection": "api", "environment": "local"})
+	if rpcErr != nil {
+		t.Fatalf("environments.get: %+v", rpcErr)
+	}
+	if e := res.(*bruno.Environment); len(e.Variables) != 3 {
+		t.Fatalf("unexpected environment: %+v", e)
+	}
+
+	res, rpcErr = callTool(t, s, "environments.list", map[string]any{"workspace": "ws", "collection": "api"})
+	if rpcErr != nil {
+		t.Fatalf("environments.list: %+v", rpcErr)
+	}
+	if envs := res.(map[string]any)["environments"].([]string); len(envs) != 1 || envs[0] != "local" {
+		t.Fatalf("unexpected environments: %v", envs)
+	}
+
+	if _, rpcErr := callTool(t, s, "environments.delete", map[string]any{"workspace": "ws", "collection": "api", "environment": "local"}); rpcErr != nil {
+		t.Fatalf("environments.delete: %+v", rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "environments.get", map[string]any{"workspace": "ws", "collection": "api", "environment": "local"}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for deleted environment, got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_RedactsSecrets(t *testing.T) {
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", ".env"), []byte("TOKEN=s3cr3t-token\n"), 0o644); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "me", "method": "get", "url": "https://example.com/me",
+		"headers": []any{map[string]any{"key": "Authorization", "value": "Bearer s3cr3t-token"}},
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "requests.get", map[string]any{"workspace": "ws", "collection": "api", "path": "me"})
+	if rpcErr != nil {
+		t.Fatalf("requests.get: %+v", rpcErr)
+	}
+	b, _ := json.Marshal(res)
+	if strings.Contains(string(b), "s3cr3t-token") || !strings.Contains(string(b), "Bearer ****") {
+		t.Fatalf("expected redacted result got %s", b)
+	}
+
+	_, rpcErr = callTool(t, s, "requests.get", map[string]any{"workspace": "ws", "collection": "api", "path": "s3cr3t-token"})
+	if rpcErr == nil || strings.Contains(rpcErr.Message, "s3cr3t-token") {
+		t.Fatalf("expected redacted error got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_RequestsRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"path":"`+r.URL.Path+`","auth":"`+r.Header.Get("Authorization")+`"}`)
+	}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", ".env"), []byte("TOKEN=run-secret\n"), 0o644); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "method": "get", "url": "{{host}}/ping",
+		"auth": map[string]any{"mode": "bearer", "bearer": map[string]any{"token": "{{process.env.TOKEN}}"}},
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "requests.run", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping",
+		"variables": map[string]any{"host": srv.URL}, "timeoutMs": 5000,
+	})
+	if rpcErr != nil {
+		t.Fatalf("requests.run: %+v", rpcErr)
+	}
+	b, _ := json.Marshal(res)
+	var out bruno.RunResult
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if out.Status != http.StatusOK || out.Body != `{"path":"/ping","auth":"Bearer ****"}` {
+		t.Fatalf("unexpected run result: %s", b)
+	}
+
+	if _, rpcErr := callTool(t, s, "requests.run", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "variables": map[string]any{"host": 1},
+	}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for non-string variable got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_RequestsRunMasksSecretVariables(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.Header.Get("X-Password"))
+	}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	if _, rpcErr := callTool(t, s, "environments.create", map[string]any{
+		"workspace": "ws", "collection": "api", "environment": "dev",
+		"variables": []any{map[string]any{"name": "password", "secret": true}},
+	}); rpcErr != nil {
+		t.Fatalf("environments.create: %+v", rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "login", "method": "get", "url": srv.URL + "/login?p={{password}}",
+		"headers": []any{map[string]any{"key": "X-Password", "value": "{{password}}"}},
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	args := map[string]any{"workspace": "ws", "collection": "api", "path": "login", "variables": map[string]any{"password": "explicit-secret"}}
+	res, rpcErr := callTool(t, s, "requests.run", args)
+	if rpcErr != nil {
+		t.Fatalf("requests.run: %+v", rpcErr)
+	}
+	if b, _ := json.Marshal(res); strings.Contains(string(b), "explicit-secret") {
+		t.Fatalf("expected the explicit secret masked: %s", b)
+	}
+
+	s.runtime.set("ws", "api", map[string]string{"password": "runtime-secret"})
+	delete(args, "variables")
+	res, rpcErr = callTool(t, s, "requests.run", args)
+	if rpcErr != nil {
+		t.Fatalf("requests.run: %+v", rpcErr)
+	}
+	if b, _ := json.Marshal(res); strings.Contains(string(b), "runtime-secret") || !strings.Contains(string(b), "****") {
+		t.Fatalf("expected the runtime secret masked: %s", b)
+	}
+}
+
+func TestToolsCall_VariablesResolve(t *testing.T) {
+	s := newWorkspaceServer(t)
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "method": "get", "url": "{{host}}/ping",
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "variables.resolve", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "variables": map[string]any{"host": "http://localhost"},
+	})
+	if rpcErr != nil {
+		t.Fatalf("variables.resolve: %+v", rpcErr)
+	}
+	r := res.(*bruno.Resolution)
+	if r.Value != "http://localhost/ping" || len(r.Variables) != 1 || r.Variables[0].Source != bruno.SourceRuntime {
+		t.Fatalf("unexpected resolution: %+v", r)
+	}
+
+	if _, rpcErr := callTool(t, s, "variables.resolve", map[string]any{"workspace": "ws", "collection": "api"}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params without input or path got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_VariablesResolveMasksProcessEnv(t *testing.T) {
+	t.Setenv("BRUNO_MCP_SERVER_ONLY_SECRET", "hunter2-server")
+	s := newWorkspaceServer(t)
+
+	res, rpcErr := callTool(t, s, "variables.resolve", map[string]any{
+		"workspace": "ws", "collection": "api", "input": "{{process.env.BRUNO_MCP_SERVER_ONLY_SECRET}}",
+	})
+	if rpcErr != nil {
+		t.Fatalf("variables.resolve: %+v", rpcErr)
+	}
+	if b, _ := json.Marshal(res); strings.Contains(string(b), "hunter2-server") || !strings.Contains(string(b), "****") {
+		t.Fatalf("expected the process.env value masked: %s", b)
+	}
+}
+
+func TestToolsCall_RequestsAssertMasksProcessEnv(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"ok":true}`)
+	}))
+	defer srv.Close()
+
+	t.Setenv("BRUNO_MCP_SERVER_ONLY_SECRET", "hunter2-server")
+	s := newWorkspaceServer(t)
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "method": "get", "url": srv.URL,
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "requests.assert", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping",
+		"assertions": []any{map[string]any{"key": "res.body.ok", "value": "eq {{process.env.BRUNO_MCP_SERVER_ONLY_SECRET}}"}},
+	})
+	if rpcErr != nil {
+		t.Fatalf("requests.assert: %+v", rpcErr)
+	}
+	if b, _ := json.Marshal(res); strings.Contains(string(b), "hunter2-server") || !strings.Contains(string(b), "****") {
+		t.Fatalf("expected the process.env value masked: %s", b)
+	}
+}
+
+func TestToolsCall_RequestsAssert(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"ok":true}`)
+	}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "method": "get", "url": srv.URL,
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "requests.assert", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping",
+		"assertions": []any{
+			map[string]any{"key": "res.status", "value": "eq 200"},
+			map[string]any{"key": "res.body.ok", "value": "isFalsy"},
+		},
+	})
+	if rpcErr != nil {
+		t.Fatalf("requests.assert: %+v", rpcErr)
+	}
+	out := res.(map[string]any)
+	if out["passed"] != false || out["total"] != 2 || out["failed"] != 1 {
+		t.Fatalf("unexpected assert result: %+v", out)
+	}
+}
+
+func TestToolsCall_RuntimeVarsCarryAcrossRuns(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			io.WriteString(w, `{"token":"t-1"}`)
+		default:
+			if r.Header.Get("Authorization") != "Bearer t-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}
+	}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", "login.bru"), []byte("post {\n  url: "+srv.URL+"/login\n}\n\nvars:post-response {\n  token: res.body.token\n}\n"), 0o644); err != nil {
+		t.Fatalf("write login: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", "me.bru"), []byte("get {\n  url: "+srv.URL+"/me\n  auth: bearer\n}\n\nauth:bearer {\n  token: {{token}}\n}\n"), 0o644); err != nil {
+		t.Fatalf("write me: %v", err)
+	}
+
+	if _, rpcErr := callTool(t, s, "requests.run", map[string]any{"workspace": "ws", "collection": "api", "path": "login"}); rpcErr != nil {
+		t.Fatalf("run login: %+v", rpcErr)
+	}
+	res, rpcErr := callTool(t, s, "requests.run", map[string]any{"workspace": "ws", "collection": "api", "path": "me"})
+	if rpcErr != nil {
+		t.Fatalf("run me: %+v", rpcErr)
+	}
+	if st := res.(*bruno.RunResult).Status; st != http.StatusOK {
+		t.Fatalf("expected runtime token to authorize second request, got %d", st)
+	}
+
+	res, rpcErr = callTool(t, s, "variables.runtime", map[string]any{"workspace": "ws", "collection": "api", "clear": true})
+	if rpcErr != nil {
+		t.Fatalf("variables.runtime: %+v", rpcErr)
+	}
+	if vars := res.(map[string]any)["variables"].(map[string]string); vars["token"] != "t-1" {
+		t.Fatalf("unexpected runtime vars: %v", vars)
+	}
+	res, _ = callTool(t, s, "requests.run", map[string]any{"workspace": "ws", "collection": "api", "path": "me"})
+	if st := res.(*bruno.RunResult).Status; st != http.StatusUnauthorized {
+		t.Fatalf("expected cleared runtime vars, got %d", st)
+	}
+}
+
+func TestToolsCall_CollectionsRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	for _, p := range []string{"users/ok", "users/fail", "other"} {
+		name := p[strings.LastIndex(p, "/")+1:]
+		if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+			"workspace": "ws", "collection": "api", "path": p, "method": "get", "url": srv.URL + "/" + name,
+		}); rpcErr != nil {
+			t.Fatalf("requests.create: %+v", rpcErr)
+		}
+		if _, rpcErr := callTool(t, s, "requests.update", map[string]any{
+			"workspace": "ws", "collection": "api", "path": p,
+			"patch": map[string]any{"setAssertions": []any{map[string]any{"key": "res.status", "value": "eq 200"}}},
+		}); rpcErr != nil {
+			t.Fatalf("requests.update: %+v", rpcErr)
+		}
+	}
+
+	res, rpcErr := callTool(t, s, "collections.run", map[string]any{
+		"workspace": "ws", "collection": "api", "folder": "users", "delayMs": 1, "exclude": []any{"other"},
+	})
+	if rpcErr != nil {
+		t.Fatalf("collections.run: %+v", rpcErr)
+	}
+	out := res.(*bruno.CollectionRunResult)
+	if out.Summary.TotalRequests != 2 || out.Summary.FailedRequests != 1 || out.Summary.PassedRequests != 1 {
+		t.Fatalf("unexpected summary: %+v", out.Summary)
+	}
+
+	if _, rpcErr := callTool(t, s, "collections.run", map[string]any{"workspace": "ws", "collection": "api", "delayMs": -1}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for negative delay got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_CollectionsIterate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if err := os.WriteFile(filepath.Join(ws.Path, "ids.json"), []byte(`[{"id": "a"}, {"id": "b"}]`), 0o644); err != nil {
+		t.Fatalf("write data: %v", err)
+	}
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "item", "method": "get", "url": srv.URL + "/items/{{id}}",
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "collections.iterate", map[string]any{"workspace": "ws", "collection": "api", "dataFile": "ids.json"})
+	if rpcErr != nil {
+		t.Fatalf("collections.iterate: %+v", rpcErr)
+	}
+	out := res.(*bruno.IterationRunResult)
+	if len(out.Iterations) != 2 || out.Iterations[1].Run.Results[0].Response.Request.URL != srv.URL+"/items/b" {
+		t.Fatalf("unexpected iterations: %+v", out.Iterations)
+	}
+
+	if _, rpcErr := callTool(t, s, "collections.iterate", map[string]any{"workspace": "ws", "collection": "api", "dataFile": "missing.csv"}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for missing data file got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_RunReports(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "method": "get", "url": srv.URL + "/ping",
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "collections.run", map[string]any{
+		"workspace": "ws", "collection": "api",
+		"reports": map[string]any{"junit": "out/junit.xml", "json": "out/report.json", "html": "out/report.html"},
+	})
+	if rpcErr != nil {
+		t.Fatalf("collections.run: %+v", rpcErr)
+	}
+	out := res.(map[string]any)
+	if paths, _ := out["reports"].([]string); len(paths) != 3 {
+		t.Fatalf("expected 3 report paths got %v", out["reports"])
+	}
+	b, err := os.ReadFile(filepath.Join(ws.Path, "out", "junit.xml"))
+	if err != nil || !strings.Contains(string(b), `<testsuite name="ping"`) {
+		t.Fatalf("unexpected junit report: %v %s", err, b)
+	}
+
+	if _, rpcErr := callTool(t, s, "requests.run", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "reports": map[string]any{"html": "../escape.html"},
+	}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for report outside workspace got %+v", rpcErr)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(ws.Path), "escape.html")); !os.IsNotExist(err) {
+		t.Fatalf("expected no report outside the workspace")
+	}
+}
+
+func TestToolsCall_RunReportsMaskSecrets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Echo", r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", ".env"), []byte("TOKEN=report-secret\n"), 0o644); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "method": "get", "url": srv.URL + "/ping",
+		"auth": map[string]any{"mode": "bearer", "bearer": map[string]any{"token": "{{process.env.TOKEN}}"}},
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	files := map[string]any{"junit": "out/junit.xml", "json": "out/report.json", "html": "out/report.html"}
+	if _, rpcErr := callTool(t, s, "requests.run", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "ping", "reports": files,
+	}); rpcErr != nil {
+		t.Fatalf("requests.run: %+v", rpcErr)
+	}
+	for _, f := range files {
+		b, err := os.ReadFile(filepath.Join(ws.Path, f.(string)))
+		if err != nil {
+			t.Fatalf("read %s: %v", f, err)
+		}
+		if strings.Contains(string(b), "report-secret") {
+			t.Fatalf("expected the secret masked in %s: %s", f, b)
+		}
+		if f == "out/report.json" && !strings.Contains(string(b), "Bearer ****") {
+			t.Fatalf("expected the masked header in %s: %s", f, b)
+		}
+	}
+}
+
+func TestToolsCall_RequestsRunMasksDerivedCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", ".env"), []byte("PASSWORD=s3cr3t-pass\nAPI_KEY=k&y=1\n"), 0o644); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+	for path, auth := range map[string]map[string]any{
+		"basic":  {"mode": "basic", "basic": map[string]any{"username": "bob", "password": "{{process.env.PASSWORD}}"}},
+		"apikey": {"mode": "apikey", "apikey": map[string]any{"key": "key", "value": "{{process.env.API_KEY}}", "placement": "queryparams"}},
+	} {
+		if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+			"workspace": "ws", "collection": "api", "path": path, "method": "get", "url": srv.URL, "auth": auth,
+		}); rpcErr != nil {
+			t.Fatalf("requests.create %s: %+v", path, rpcErr)
+		}
+	}
+
+	leaks := []string{base64.StdEncoding.EncodeToString([]byte("bob:s3cr3t-pass")), url.QueryEscape("k&y=1")}
+	for _, path := range []string{"basic", "apikey"} {
+		res, rpcErr := callTool(t, s, "requests.run", map[string]any{"workspace": "ws", "collection": "api", "path": path})
+		if rpcErr != nil {
+			t.Fatalf("requests.run %s: %+v", path, rpcErr)
+		}
+		b, _ := json.Marshal(res)
+		runs, _ := os.ReadDir(filepath.Join(ws.Path, ".bruno-mcp", "runs"))
+		for _, e := range runs {
+			stored, _ := os.ReadFile(filepath.Join(ws.Path, ".bruno-mcp", "runs", e.Name()))
+			b = append(b, stored...)
+		}
+		for _, leak := range leaks {
+			if strings.Contains(string(b), leak) {
+				t.Fatalf("%s: expected %q masked: %s", path, leak, b)
+			}
+		}
+	}
+}
+
+func TestToolsCall_BruRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bru is a shell script")
+	}
+	bin := t.TempDir()
+	script := "#!/bin/sh\nif [ \"$1\" = --version ]; then echo 1.38.1; exit 0; fi\nwhile [ $# -gt 0 ]; do\n  if [ \"$1\" = \"--reporter-json\" ]; then printf '%s' \"$FAKE_BRU_REPORT\" > \"$2\"; fi\n  shift\ndone\n"
+	if err := os.WriteFile(filepath.Join(bin, "bru"), []byte(script), 0o755); err != nil {
+		t.Fatalf("write fake bru: %v", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_BRU_REPORT", `[{"iterationIndex":0,"summary":{"totalRequests":1,"passedRequests":1},"results":[{"test":{"filename":"ping.bru"},"request":{"method":"GET","url":"http://x/ping","headers":{}},"response":{"status":200,"statusText":"OK","headers":{},"data":"pong","responseTime":1},"error":null,"status":"pass","assertionResults":[],"testResults":[{"description":"is pong","status":"pass"}],"runtime":0.001,"suitename":"ping","iterationIndex":0}]}]`)
+
+	s := newWorkspaceServer(t)
+	res, rpcErr := callTool(t, s, "bru.run", map[string]any{"workspace": "ws", "collection": "api"})
+	if rpcErr != nil {
+		t.Fatalf("bru.run: %+v", rpcErr)
+	}
+	out := res.(*bruno.CLIRunResult)
+	if !out.Passed() || out.Summary.PassedRequests != 1 || out.Iterations[0].Results[0].TestResults[0].Description != "is pong" {
+		t.Fatalf("unexpected result: %+v", out)
+	}
+
+	if _, rpcErr := callTool(t, s, "bru.run", map[string]any{"workspace": "ws", "collection": "api", "folder": "missing"}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for missing folder got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_BrunoInfoWithoutCLI(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	s := newWorkspaceServer(t)
+
+	res, rpcErr := callTool(t, s, "bruno.info", map[string]any{})
+	if rpcErr != nil {
+		t.Fatalf("bruno.info: %+v", rpcErr)
+	}
+	if info := res.(*bruno.CLIInfo); info.Available || info.Supported {
+		t.Fatalf("expected bru to be unavailable, got %+v", info)
+	}
+
+	_, rpcErr = callTool(t, s, "bru.run", map[string]any{"workspace": "ws", "collection": "api"})
+	if rpcErr == nil || !strings.Contains(rpcErr.Message, "bru cli not found") {
+		t.Fatalf("expected bru not found error got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_BruRunWithOldCLI(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bru is a shell script")
+	}
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "bru"), []byte("#!/bin/sh\necho 0.9.0\n"), 0o755); err != nil {
+		t.Fatalf("write fake bru: %v", err)
+	}
+	t.Setenv("PATH", bin)
+	s := newWorkspaceServer(t)
+
+	_, rpcErr := callTool(t, s, "bru.run", map[string]any{"workspace": "ws", "collection": "api"})
+	if rpcErr == nil || !strings.Contains(rpcErr.Message, "upgrade it with") {
+		t.Fatalf("expected an upgrade hint got %+v", rpcErr)
+	}
+}
+
+func TestInitialize_BoundsTheCLIProbe(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bru is a shell script")
+	}
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "bru"), []byte("#!/bin/sh\nsleep 0.3\necho 1.38.1\n"), 0o755); err != nil {
+		t.Fatalf("write fake bru: %v", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	defer func(d time.Duration) { cliInfoWait = d }(cliInfoWait)
+	cliInfoWait = 50 * time.Millisecond
+
+	s := NewServer()
+	s.RegisterCoreMethods()
+	capabilities := func() map[string]any {
+		var res struct {
+			Capabilities map[string]any `json:"capabilities"`
+		}
+		s.session = session{}
+		if rpcErr := dispatchMethod(t, s, "initialize", map[string]any{"protocolVersion": "2025-06-18"}, &res); rpcErr != nil {
+			t.Fatalf("initialize: %+v", rpcErr)
+		}
+		return res.Capabilities
+	}
+	start := time.Now()
+	if _, ok := capabilities()["experimental"]; ok || time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("expected initialize not to wait for a slow probe")
+	}
+
+	s.bruno.CLIInfo()
+	if _, ok := capabilities()["experimental"]; !ok {
+		t.Fatalf("expected the finished probe in the capabilities")
+	}
+}
+
+func TestToolsCall_RunHistory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "auth="+r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", ".env"), []byte("TOKEN=history-secret\n"), 0o644); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "me", "method": "get", "url": srv.URL + "/me",
+		"headers": []any{map[string]any{"key": "Authorization", "value": "Bearer {{process.env.TOKEN}}"}},
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+	for i := 0; i < 2; i++ {
+		if _, rpcErr := callTool(t, s, "requests.run", map[string]any{"workspace": "ws", "collection": "api", "path": "me"}); rpcErr != nil {
+			t.Fatalf("requests.run: %+v", rpcErr)
+		}
+	}
+	if _, rpcErr := callTool(t, s, "collections.run", map[string]any{"workspace": "ws", "collection": "api"}); rpcErr != nil {
+		t.Fatalf("collections.run: %+v", rpcErr)
+	}
+
+	res, rpcErr := callTool(t, s, "runs.list", map[string]any{"workspace": "ws", "target": "me"})
+	if rpcErr != nil {
+		t.Fatalf("runs.list: %+v", rpcErr)
+	}
+	runs := res.(map[string]any)["runs"].([]history.Summary)
+	if len(runs) != 2 || runs[0].Kind != history.KindRequest || !runs[0].StartedAt.After(runs[1].StartedAt) {
+		t.Fatalf("unexpected runs: %+v", runs)
+	}
+
+	res, rpcErr = callTool(t, s, "runs.get", map[string]any{"workspace": "ws", "id": runs[0].ID})
+	if rpcErr != nil {
+		t.Fatalf("runs.get: %+v", rpcErr)
+	}
+	e := res.(*history.Entry)
+	if e.Requests[0].Response.Status != 200 || e.Requests[0].Request.URL != srv.URL+"/me" {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+	b, _ := os.ReadFile(filepath.Join(ws.Path, ".bruno-mcp", "runs", runs[0].ID+".json"))
+	if strings.Contains(string(b), "history-secret") || !strings.Contains(string(b), "Bearer ****") {
+		t.Fatalf("expected secrets masked in stored run: %s", b)
+	}
+
+	if _, rpcErr := callTool(t, s, "runs.delete", map[string]any{"workspace": "ws", "id": runs[0].ID}); rpcErr != nil {
+		t.Fatalf("runs.delete: %+v", rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "runs.get", map[string]any{"workspace": "ws", "id": runs[0].ID}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params for deleted run got %+v", rpcErr)
+	}
+	res, rpcErr = callTool(t, s, "runs.delete", map[string]any{"workspace": "ws", "all": true})
+	if rpcErr != nil || res.(map[string]any)["deleted"] != 2 {
+		t.Fatalf("runs.delete all: %+v %+v", res, rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "runs.delete", map[string]any{"workspace": "ws"}); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+		t.Fatalf("expected invalid params without id got %+v", rpcErr)
+	}
+}
+
+func TestToolsCall_RunHistoryRecordsFailedRequests(t *testing.T) {
+	s := newWorkspaceServer(t)
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "down", "method": "get", "url": "http://127.0.0.1:1/",
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+	args := map[string]any{"workspace": "ws", "collection": "api", "path": "down"}
+	for _, tool := range []string{"requests.run", "requests.assert"} {
+		if _, rpcErr := callTool(t, s, tool, args); rpcErr == nil {
+			t.Fatalf("%s: expected the connection to fail", tool)
+		}
+	}
+	if _, rpcErr := callTool(t, s, "requests.run", map[string]any{"workspace": "ws", "collection": "api", "path": "missing"}); rpcErr == nil {
+		t.Fatalf("expected a missing request to fail")
+	}
+
+	res, rpcErr := callTool(t, s, "runs.list", map[string]any{"workspace": "ws"})
+	if rpcErr != nil {
+		t.Fatalf("runs.list: %+v", rpcErr)
+	}
+	runs := res.(map[string]any)["runs"].([]history.Summary)
+	if len(runs) != 2 {
+		t.Fatalf("expected the two failed runs recorded, got %+v", runs)
+	}
+	res, rpcErr = callTool(t, s, "runs.get", map[string]any{"workspace": "ws", "id": runs[0].ID})
+	if rpcErr != nil {
+		t.Fatalf("runs.get: %+v", rpcErr)
+	}
+	e := res.(*history.Entry)
+	if e.Passed || e.Summary.ErrorRequests != 1 || len(e.Requests) != 1 || e.Requests[0].Error == "" || e.Requests[0].Response != nil {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+}
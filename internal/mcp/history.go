@@ -0,0 +1,46 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+	"github.com/Mayank2930/bruno-mcp-server/internal/history"
+	"github.com/Mayank2930/bruno-mcp-server/internal/report"
+)
+
+// recordRun stores a run in the workspace history with secrets masked. A
+// failure to record is logged and does not fail the tool call.
+func (s *Server) recordRun(workspaceDir string, e *history.Entry) {
+	s.collectSecrets(e.Inputs)
+	e, err := redactedCopy(s, e)
+	if err != nil {
+		fmt.Fprintf(s.stderr, "record run: %v\n", err)
+		return
+	}
+	if err := history.NewStore(workspaceDir).Save(e); err != nil {
+		fmt.Fprintf(s.stderr, "record run: %v\n", err)
+	}
+}
+
+// recordFailedRun stores a request that was sent but got no response, such as
+// a refused connection or a timeout. Requests that could not be loaded or
+// built never ran and are not recorded.
+func (s *Server) recordFailedRun(workspaceDir, col, relPath string, opts bruno.RunOptions, args map[string]any, started time.Time, err error) {
+	if !errors.Is(err, bruno.ErrRequestFailed) {
+		return
+	}
+	run := report.FromRequestError(col, relPath, err)
+	s.recordRun(workspaceDir, history.NewEntry(history.KindRequest, relPath, opts.Environment, args, started, run))
+}
+
+func historyToRPCError(err error) *RPCError {
+	switch {
+	case errors.Is(err, history.ErrNotFound),
+		errors.Is(err, history.ErrInvalidID):
+		return NewError(CodeInvalidParams, err.Error())
+	default:
+		return NewError(CodeInternalError, err.Error())
+	}
+}
@@ -1,6 +1,12 @@
 package mcp
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+)
 
 func decodeParams[T any](req Request) (T, *RPCError) {
 	var zero T
@@ -27,3 +33,72 @@ func decodeParamsOptional[T any](req Request) (T, *RPCError) {
 
 	return zero, nil
 }
+
+// decodeArg converts a tool argument into T by way of its JSON form. Unknown
+// fields are rejected so that misspelt options are reported instead of
+// silently ignored.
+func decodeArg[T any](args map[string]any, key string) (T, *RPCError) {
+	var zero T
+	v, ok := args[key]
+	if !ok || v == nil {
+		return zero, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return zero, NewError(CodeInvalidParams, "Invalid params: "+key+": "+err.Error())
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&zero); err != nil {
+		return zero, NewError(CodeInvalidParams, "Invalid params: "+key+": "+err.Error())
+	}
+	return zero, nil
+}
+
+// runOptions reads the environment, variables and timeoutMs arguments shared
+// by the tools that execute requests.
+func runOptions(args map[string]any) (bruno.RunOptions, *RPCError) {
+	var opts bruno.RunOptions
+	opts.Environment, _ = args["environment"].(string)
+
+	vars, rpcErr := decodeArg[map[string]string](args, "variables")
+	if rpcErr != nil {
+		return opts, rpcErr
+	}
+	opts.Variables = vars
+
+	if v, ok := args["timeoutMs"].(float64); ok {
+		if v <= 0 {
+			return opts, NewError(CodeInvalidParams, "Invalid params: timeoutMs must be positive")
+		}
+		opts.Timeout = time.Duration(v) * time.Millisecond
+	}
+	return opts, nil
+}
+
+// collectionRunOptions reads the arguments of the tools that run a collection.
+func collectionRunOptions(args map[string]any) (bruno.CollectionRunOptions, *RPCError) {
+	var opts bruno.CollectionRunOptions
+	run, rpcErr := runOptions(args)
+	if rpcErr != nil {
+		return opts, rpcErr
+	}
+	opts.Environment, opts.Variables, opts.Timeout = run.Environment, run.Variables, run.Timeout
+	opts.Folder, _ = args["folder"].(string)
+	opts.Bail, _ = args["bail"].(bool)
+
+	if v, ok := args["delayMs"].(float64); ok {
+		if v < 0 {
+			return opts, NewError(CodeInvalidParams, "Invalid params: delayMs must not be negative")
+		}
+		opts.Delay = time.Duration(v) * time.Millisecond
+	}
+	if opts.Include, rpcErr = decodeArg[[]string](args, "include"); rpcErr != nil {
+		return opts, rpcErr
+	}
+	if opts.Exclude, rpcErr = decodeArg[[]string](args, "exclude"); rpcErr != nil {
+		return opts, rpcErr
+	}
+	return opts, nil
+}
@@ -0,0 +1,317 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/history"
+)
+
+// maxPromptFiles bounds how many .bru files a prompt embeds.
+const maxPromptFiles = 50
+
+type Prompt struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+type PromptMessage struct {
+	Role    string        `json:"role"`
+	Content PromptContent `json:"content"`
+}
+
+// PromptContent is a text block or, with Type "resource", an embedded resource.
+type PromptContent struct {
+	Type     string            `json:"type"`
+	Text     string            `json:"text,omitempty"`
+	Resource *ResourceContents `json:"resource,omitempty"`
+}
+
+type PromptGetParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+type promptDef struct {
+	Prompt
+	build func(s *Server, args map[string]string) ([]PromptMessage, *RPCError)
+}
+
+var (
+	workspaceArg   = PromptArgument{Name: "workspace", Description: "Registered workspace name", Required: true}
+	collectionArg  = PromptArgument{Name: "collection", Description: "Collection directory inside the workspace", Required: true}
+	requestPathArg = PromptArgument{Name: "path", Description: "Request path inside the collection, e.g. users/get", Required: true}
+)
+
+var prompts = []promptDef{
+	{
+		Prompt: Prompt{
+			Name:        "write-tests",
+			Title:       "Write tests for a request",
+			Description: "Add assertions and a tests script to a request and check them by running it",
+			Arguments: []PromptArgument{workspaceArg, collectionArg, requestPathArg,
+				{Name: "environment", Description: "Environment to run the request with"}},
+		},
+		build: buildWriteTests,
+	},
+	{
+		Prompt: Prompt{
+			Name:        "create-crud-folder",
+			Title:       "Create a CRUD folder",
+			Description: "Create a folder with list, get, create, update and delete requests for a REST resource",
+			Arguments: []PromptArgument{workspaceArg, collectionArg,
+				{Name: "resource", Description: "REST resource name, e.g. users", Required: true},
+				{Name: "baseUrl", Description: "Base URL or variable of the API; defaults to {{baseUrl}}"}},
+		},
+		build: buildCreateCRUDFolder,
+	},
+	{
+		Prompt: Prompt{
+			Name:        "debug-request",
+			Title:       "Debug a failing request",
+			Description: "Find out why a request or its assertions fail, using its last recorded run",
+			Arguments: []PromptArgument{workspaceArg, collectionArg, requestPathArg,
+				{Name: "environment", Description: "Environment the request fails in"}},
+		},
+		build: buildDebugRequest,
+	},
+	{
+		Prompt: Prompt{
+			Name:        "document-collection",
+			Title:       "Document a collection",
+			Description: "Write docs for a collection and its requests from their .bru files",
+			Arguments:   []PromptArgument{workspaceArg, collectionArg},
+		},
+		build: buildDocumentCollection,
+	},
+}
+
+func (s *Server) handlePromptsList(ctx context.Context, req Request) (any, *RPCError) {
+	list := make([]Prompt, 0, len(prompts))
+	for _, p := range prompts {
+		list = append(list, p.Prompt)
+	}
+	return map[string]any{"prompts": list}, nil
+}
+
+func (s *Server) handlePromptsGet(ctx context.Context, req Request) (any, *RPCError) {
+	params, rpcErr := decodeParams[PromptGetParams](req)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	for _, p := range prompts {
+		if p.Name != params.Name {
+			continue
+		}
+		for _, a := range p.Arguments {
+			if a.Required && strings.TrimSpace(params.Arguments[a.Name]) == "" {
+				return nil, NewError(CodeInvalidParams, fmt.Sprintf("Invalid params: prompt %q requires argument %q", p.Name, a.Name))
+			}
+		}
+		msgs, rpcErr := p.build(s, params.Arguments)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		return map[string]any{"description": p.Description, "messages": msgs}, nil
+	}
+	return nil, NewError(CodeInvalidParams, fmt.Sprintf("Invalid params: unknown prompt %q", params.Name))
+}
+
+func userText(text string) PromptMessage {
+	return PromptMessage{Role: "user", Content: PromptContent{Type: "text", Text: text}}
+}
+
+// embed reads a collection file as an embedded resource message.
+func (s *Server) embed(wsName, col, rel string) (PromptMessage, *RPCError) {
+	contents, rpcErr := s.resourceContents(wsName, col, rel, resourceURI(wsName, col, rel))
+	if rpcErr != nil {
+		return PromptMessage{}, rpcErr
+	}
+	return PromptMessage{Role: "user", Content: PromptContent{Type: "resource", Resource: &contents}}, nil
+}
+
+func bruPath(p string) string {
+	p = strings.TrimPrefix(strings.TrimSpace(p), "/")
+	if !strings.HasSuffix(p, ".bru") {
+		p += ".bru"
+	}
+	return p
+}
+
+// promptTarget formats the arguments that address a collection in tool calls.
+func promptTarget(args map[string]string) string {
+	return fmt.Sprintf("workspace %q, collection %q", args["workspace"], args["collection"])
+}
+
+func envClause(args map[string]string) string {
+	if env := args["environment"]; env != "" {
+		return fmt.Sprintf(" with environment %q", env)
+	}
+	return ""
+}
+
+func buildWriteTests(s *Server, args map[string]string) ([]PromptMessage, *RPCError) {
+	path := bruPath(args["path"])
+	req, rpcErr := s.embed(args["workspace"], args["collection"], path)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return []PromptMessage{
+		req,
+		userText(fmt.Sprintf(`Write tests for the Bruno request %s (%s).
+
+1. Run it once with requests.run%s to see a real response.
+2. Add declarative assertions with requests.update setAssertions: the status code, the content type and the important body fields (e.g. res.body.id isDefined).
+3. Put checks that need logic, such as array lengths or relations between fields, in the tests script using test() and expect().
+4. Keep what the request already has; only add to it.
+5. Verify with requests.assert%s and fix the tests until they pass, unless the API itself is wrong. Report anything that looks like an API bug instead of asserting it.`,
+			path, promptTarget(args), envClause(args), envClause(args))),
+	}, nil
+}
+
+func buildCreateCRUDFolder(s *Server, args map[string]string) ([]PromptMessage, *RPCError) {
+	cfg, rpcErr := s.embed(args["workspace"], args["collection"], "bruno.json")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	resource := strings.Trim(args["resource"], "/ ")
+	base := args["baseUrl"]
+	if base == "" {
+		base = "{{baseUrl}}"
+	}
+	return []PromptMessage{
+		cfg,
+		userText(fmt.Sprintf(`Create a folder %q in %s with CRUD requests for the %s resource.
+
+1. Create the folder with folders.create.
+2. Create these requests with requests.create, in this order:
+   - list: GET %[4]s/%[3]s
+   - get: GET %[4]s/%[3]s/{{%[5]sId}}
+   - create: POST %[4]s/%[3]s with a JSON body of example fields
+   - update: PUT %[4]s/%[3]s/{{%[5]sId}} with a JSON body
+   - delete: DELETE %[4]s/%[3]s/{{%[5]sId}}
+3. In create, capture the new id with a post-response variable %[5]sId = res.body.id so that the later requests can use it.
+4. Give every request a status assertion and a short docs block.
+5. If the URL uses variables that no environment defines yet, say which ones need to be added.`,
+			resource, promptTarget(args), resource, base, singular(resource))),
+	}, nil
+}
+
+func buildDebugRequest(s *Server, args map[string]string) ([]PromptMessage, *RPCError) {
+	path := bruPath(args["path"])
+	msgs := []PromptMessage{}
+	req, rpcErr := s.embed(args["workspace"], args["collection"], path)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	msgs = append(msgs, req)
+
+	if env := args["environment"]; env != "" {
+		m, rpcErr := s.embed(args["workspace"], args["collection"], "environments/"+env+".bru")
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		msgs = append(msgs, m)
+	}
+
+	last := "There is no recorded run of this request yet; run it with requests.run first."
+	if run := s.lastRun(args["workspace"], args["collection"], path); run != "" {
+		last = "The last recorded run of this request:\n\n" + run
+	}
+	msgs = append(msgs, userText(fmt.Sprintf(`The Bruno request %s (%s) is failing%s. Find out why.
+
+%s
+
+1. Compare the sent request with the .bru file: are all {{variables}} resolved? Use variables.resolve to see where each value comes from.
+2. Look at the status, headers and body of the response and at every failed assertion.
+3. Decide whether the request, the environment, the assertions or the API is at fault.
+4. Fix the request or its assertions if they are wrong and run it again to confirm. If the API is at fault, explain what it returns and what was expected instead.`,
+		path, promptTarget(args), envClause(args), last)))
+	return msgs, nil
+}
+
+func buildDocumentCollection(s *Server, args map[string]string) ([]PromptMessage, *RPCError) {
+	wsName, col := args["workspace"], args["collection"]
+	ws, err := s.registry.Get(wsName)
+	if err != nil {
+		return nil, resourceError(err, resourceURI(wsName, col))
+	}
+	reqs, err := s.bruno.ListRequests(ws.Path, col)
+	if err != nil {
+		return nil, resourceError(err, resourceURI(wsName, col))
+	}
+	sort.Strings(reqs)
+
+	var msgs []PromptMessage
+	for _, rel := range append([]string{"bruno.json"}, reqs...) {
+		if len(msgs) == maxPromptFiles {
+			break
+		}
+		m, rpcErr := s.embed(wsName, col, rel)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		msgs = append(msgs, m)
+	}
+
+	embedded := len(msgs) - 1
+	note := ""
+	if embedded < len(reqs) {
+		note = fmt.Sprintf("\nThe collection has %d request files; read the other %d with requests.get.\n", len(reqs), len(reqs)-embedded)
+	}
+	msgs = append(msgs, userText(fmt.Sprintf(`Document the Bruno collection %s. Its bruno.json and %d request files are above.
+%s
+1. Write collection docs with collections.update: what the API is for, how to authenticate and which environment variables it needs.
+2. Give every folder a docs block with folders.update describing the resource it groups.
+3. Give every request a docs block with requests.update: what it does, its parameters and body, and an example response if one is known.
+4. Keep existing docs that are still accurate and do not change anything besides docs.`,
+		promptTarget(args), embedded, note)))
+	return msgs, nil
+}
+
+// lastRun returns the newest stored run of a request as JSON, or "" if there
+// is none. Stored runs are already redacted.
+func (s *Server) lastRun(wsName, col, path string) string {
+	ws, err := s.registry.Get(wsName)
+	if err != nil {
+		return ""
+	}
+	store := history.NewStore(ws.Path)
+	runs, err := store.List(history.Filter{Collection: col, Target: path, Limit: 1})
+	if err != nil || len(runs) == 0 {
+		return ""
+	}
+	e, err := store.Get(runs[0].ID)
+	if err != nil {
+		return ""
+	}
+	b, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// singular makes a variable name for one item of a REST resource.
+func singular(resource string) string {
+	name := resource[strings.LastIndex(resource, "/")+1:]
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
@@ -0,0 +1,136 @@
+package mcp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type promptResult struct {
+	Description string          `json:"description"`
+	Messages    []PromptMessage `json:"messages"`
+}
+
+func TestPrompts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "users/get", "method": "GET", "url": srv.URL + "/users/1",
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+
+	var list struct {
+		Prompts []Prompt `json:"prompts"`
+	}
+	if rpcErr := dispatchMethod(t, s, "prompts/list", nil, &list); rpcErr != nil {
+		t.Fatalf("prompts/list: %+v", rpcErr)
+	}
+	names := map[string]bool{}
+	for _, p := range list.Prompts {
+		names[p.Name] = true
+	}
+	for _, want := range []string{"write-tests", "create-crud-folder", "debug-request", "document-collection"} {
+		if !names[want] {
+			t.Fatalf("expected prompt %s, got %+v", want, list.Prompts)
+		}
+	}
+
+	var res promptResult
+	args := map[string]any{"workspace": "ws", "collection": "api", "path": "users/get"}
+	if rpcErr := dispatchMethod(t, s, "prompts/get", map[string]any{"name": "write-tests", "arguments": args}, &res); rpcErr != nil {
+		t.Fatalf("prompts/get write-tests: %+v", rpcErr)
+	}
+	if len(res.Messages) != 2 {
+		t.Fatalf("expected embedded request and instructions, got %+v", res.Messages)
+	}
+	embedded := res.Messages[0].Content
+	if embedded.Type != "resource" || embedded.Resource.URI != "bruno://ws/api/users/get.bru" || !strings.Contains(embedded.Resource.Text, srv.URL+"/users/1") {
+		t.Fatalf("unexpected embedded resource: %+v", embedded)
+	}
+	if text := res.Messages[1].Content.Text; !strings.Contains(text, "requests.assert") || !strings.Contains(text, `workspace "ws"`) {
+		t.Fatalf("unexpected instructions: %s", text)
+	}
+
+	if rpcErr := dispatchMethod(t, s, "prompts/get", map[string]any{"name": "debug-request", "arguments": args}, &res); rpcErr != nil {
+		t.Fatalf("prompts/get debug-request: %+v", rpcErr)
+	}
+	if text := res.Messages[len(res.Messages)-1].Content.Text; !strings.Contains(text, "no recorded run") {
+		t.Fatalf("expected a note about the missing run, got %s", text)
+	}
+	if _, rpcErr := callTool(t, s, "requests.run", args); rpcErr != nil {
+		t.Fatalf("requests.run: %+v", rpcErr)
+	}
+	if rpcErr := dispatchMethod(t, s, "prompts/get", map[string]any{"name": "debug-request", "arguments": args}, &res); rpcErr != nil {
+		t.Fatalf("prompts/get debug-request: %+v", rpcErr)
+	}
+	if text := res.Messages[len(res.Messages)-1].Content.Text; !strings.Contains(text, `"status": 404`) {
+		t.Fatalf("expected the last run embedded, got %s", text)
+	}
+
+	if rpcErr := dispatchMethod(t, s, "prompts/get", map[string]any{"name": "create-crud-folder", "arguments": map[string]any{
+		"workspace": "ws", "collection": "api", "resource": "orders",
+	}}, &res); rpcErr != nil {
+		t.Fatalf("prompts/get create-crud-folder: %+v", rpcErr)
+	}
+	if text := res.Messages[1].Content.Text; !strings.Contains(text, "GET {{baseUrl}}/orders/{{orderId}}") {
+		t.Fatalf("unexpected CRUD instructions: %s", text)
+	}
+
+	if rpcErr := dispatchMethod(t, s, "prompts/get", map[string]any{"name": "document-collection", "arguments": map[string]any{
+		"workspace": "ws", "collection": "api",
+	}}, &res); rpcErr != nil {
+		t.Fatalf("prompts/get document-collection: %+v", rpcErr)
+	}
+	if len(res.Messages) != 3 || res.Messages[0].Content.Resource.URI != "bruno://ws/api/bruno.json" {
+		t.Fatalf("expected bruno.json and the request embedded, got %+v", res.Messages)
+	}
+	if text := res.Messages[2].Content.Text; !strings.Contains(text, "bruno.json and 1 request files are above") || strings.Contains(text, "requests.get") {
+		t.Fatalf("unexpected document instructions: %s", text)
+	}
+
+	for _, bad := range []map[string]any{
+		{"name": "nope"},
+		{"name": "write-tests", "arguments": map[string]any{"workspace": "ws", "collection": "api"}},
+	} {
+		if rpcErr := dispatchMethod(t, s, "prompts/get", bad, &res); rpcErr == nil || rpcErr.Code != CodeInvalidParams {
+			t.Fatalf("%v: expected invalid params, got %+v", bad, rpcErr)
+		}
+	}
+	missing := map[string]any{"name": "write-tests", "arguments": map[string]any{"workspace": "ws", "collection": "api", "path": "missing"}}
+	if rpcErr := dispatchMethod(t, s, "prompts/get", missing, &res); rpcErr == nil || rpcErr.Code != CodeResourceNotFound {
+		t.Fatalf("expected resource not found, got %+v", rpcErr)
+	}
+}
+
+func TestPrompts_DocumentLargeCollection(t *testing.T) {
+	s := newWorkspaceServer(t)
+	for i := 0; i < maxPromptFiles+5; i++ {
+		if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+			"workspace": "ws", "collection": "api", "path": fmt.Sprintf("r%02d", i), "method": "GET", "url": "https://example.com",
+		}); rpcErr != nil {
+			t.Fatalf("requests.create: %+v", rpcErr)
+		}
+	}
+
+	var res promptResult
+	if rpcErr := dispatchMethod(t, s, "prompts/get", map[string]any{"name": "document-collection", "arguments": map[string]any{
+		"workspace": "ws", "collection": "api",
+	}}, &res); rpcErr != nil {
+		t.Fatalf("prompts/get document-collection: %+v", rpcErr)
+	}
+	if len(res.Messages) != maxPromptFiles+1 {
+		t.Fatalf("expected %d embedded files and instructions, got %d messages", maxPromptFiles, len(res.Messages))
+	}
+	text := res.Messages[maxPromptFiles].Content.Text
+	want := fmt.Sprintf("bruno.json and %d request files are above", maxPromptFiles-1)
+	if !strings.Contains(text, want) || !strings.Contains(text, "read the other 6 with requests.get") {
+		t.Fatalf("unexpected document instructions: %s", text)
+	}
+}
@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+)
+
+// collectSecrets registers the secret values of the collection a tool call
+// addressed, including the values given for its vars:secret variables.
+// Lookup failures are ignored; the call has already reported them.
+func (s *Server) collectSecrets(args map[string]any) {
+	wsName, _ := args["workspace"].(string)
+	col, _ := args["collection"].(string)
+	if wsName == "" || col == "" {
+		return
+	}
+	ws, err := s.registry.Get(wsName)
+	if err != nil {
+		return
+	}
+	values, err := s.bruno.SecretValues(ws.Path, col)
+	if err != nil {
+		return
+	}
+	s.secrets.Add(values...)
+
+	// Values of vars:secret variables come from the caller, either in this
+	// call or earlier in the session as runtime variables.
+	names, _ := s.bruno.SecretNames(ws.Path, col)
+	if len(names) == 0 {
+		return
+	}
+	runtime := s.runtime.get(wsName, col)
+	explicit, _ := args["variables"].(map[string]any)
+	for _, name := range names {
+		if v, ok := explicit[name].(string); ok {
+			s.secrets.Add(v)
+		}
+		if v, ok := runtime[name]; ok {
+			s.secrets.Add(v)
+		}
+	}
+}
+
+// redactResult masks secret values in a tool result. The result is matched
+// in its JSON form and only re-encoded when a secret actually occurs in it.
+func (s *Server) redactResult(res any) any {
+	values := s.secrets.Values()
+	if len(values) == 0 || res == nil {
+		return res
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		return res
+	}
+
+	out := b
+	for _, v := range values {
+		enc, _ := json.Marshal(v)
+		out = bytes.ReplaceAll(out, enc[1:len(enc)-1], []byte(bruno.Redacted))
+	}
+	if bytes.Equal(out, b) {
+		return res
+	}
+	return json.RawMessage(out)
+}
+
+// redactedCopy returns v with secret values masked, decoded back into its
+// own type. v itself is returned when nothing needed masking.
+func redactedCopy[T any](s *Server, v *T) (*T, error) {
+	b, ok := s.redactResult(v).(json.RawMessage)
+	if !ok {
+		return v, nil
+	}
+	var out T
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *Server) redactError(e *RPCError) *RPCError {
+	if e == nil {
+		return nil
+	}
+	r := *e
+	r.Message = s.secrets.Redact(e.Message)
+	if e.Data != nil {
+		if d, ok := s.redactResult(e.Data).(json.RawMessage); ok {
+			r.Data = nil
+			_ = json.Unmarshal(d, &r.Data)
+		}
+	}
+	return &r
+}
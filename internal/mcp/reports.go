@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/report"
+)
+
+var reportsSchema = map[string]any{
+	"type":        "object",
+	"description": "Report files to write, relative to the workspace",
+	"properties": map[string]any{
+		"junit": map[string]any{"type": "string"},
+		"json":  map[string]any{"type": "string"},
+		"html":  map[string]any{"type": "string"},
+	},
+}
+
+// withReportPaths adds the "reports" output property to a tool output schema.
+func withReportPaths(schema map[string]any) map[string]any {
+	props := map[string]any{"reports": stringListSchema}
+	for k, v := range schema["properties"].(map[string]any) {
+		props[k] = v
+	}
+	out := map[string]any{}
+	for k, v := range schema {
+		out[k] = v
+	}
+	out["properties"] = props
+	return out
+}
+
+// reportTargets reads the reports argument and checks its paths against the
+// workspace before anything runs.
+func reportTargets(args map[string]any, workspaceDir string) (report.Targets, *RPCError) {
+	t, rpcErr := decodeArg[report.Targets](args, "reports")
+	if rpcErr != nil {
+		return t, rpcErr
+	}
+	if err := t.Validate(workspaceDir); err != nil {
+		return t, reportToRPCError(err)
+	}
+	return t, nil
+}
+
+// writeReports renders run into the requested files, with the secrets of
+// the called collection masked as in the run history, and returns res with
+// the written paths added under "reports". Without targets res is returned
+// unchanged.
+func (s *Server) writeReports(workspaceDir string, args map[string]any, t report.Targets, run *report.Run, res any) (any, *RPCError) {
+	if t.Empty() {
+		return res, nil
+	}
+	s.collectSecrets(args)
+	run, err := redactedCopy(s, run)
+	if err != nil {
+		return nil, NewError(CodeInternalError, err.Error())
+	}
+	paths, err := report.WriteFiles(workspaceDir, t, run)
+	if err != nil {
+		return nil, reportToRPCError(err)
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return nil, NewError(CodeInternalError, err.Error())
+	}
+	var out map[string]any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, NewError(CodeInternalError, err.Error())
+	}
+	out["reports"] = paths
+	return out, nil
+}
+
+func reportToRPCError(err error) *RPCError {
+	if errors.Is(err, report.ErrInvalidPath) {
+		return NewError(CodeInvalidParams, "Invalid params: reports: "+err.Error())
+	}
+	return NewError(CodeInternalError, err.Error())
+}
@@ -0,0 +1,263 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+	"github.com/Mayank2930/bruno-mcp-server/internal/workspace"
+)
+
+const (
+	resourceScheme = "bruno://"
+	bruMimeType    = "text/x-bru"
+	jsonMimeType   = "application/json"
+)
+
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text"`
+}
+
+type ResourceReadParams struct {
+	URI string `json:"uri"`
+}
+
+// resourceURI builds bruno://<workspace>/<collection>/<path> from unescaped
+// segments; a path segment may itself contain slashes. Empty segments are
+// left out.
+func resourceURI(segments ...string) string {
+	var parts []string
+	for _, seg := range segments {
+		if seg == "" {
+			continue
+		}
+		for _, p := range strings.Split(seg, "/") {
+			parts = append(parts, url.PathEscape(p))
+		}
+	}
+	return resourceScheme + strings.Join(parts, "/")
+}
+
+// parseResourceURI splits a bruno:// URI into workspace, collection and the
+// file path inside the collection; trailing parts may be empty.
+func parseResourceURI(uri string) (ws, col, rel string, ok bool) {
+	rest, found := strings.CutPrefix(uri, resourceScheme)
+	if !found || rest == "" {
+		return "", "", "", false
+	}
+	parts := strings.Split(strings.TrimSuffix(rest, "/"), "/")
+	for i, p := range parts {
+		u, err := url.PathUnescape(p)
+		if err != nil || u == "" {
+			return "", "", "", false
+		}
+		parts[i] = u
+	}
+	ws = parts[0]
+	if len(parts) > 1 {
+		col = parts[1]
+	}
+	if len(parts) > 2 {
+		rel = path.Join(parts[2:]...)
+	}
+	return ws, col, rel, true
+}
+
+func (s *Server) handleResourcesList(ctx context.Context, req Request) (any, *RPCError) {
+	resources := []Resource{}
+	for _, ws := range s.registry.List() {
+		resources = append(resources, Resource{
+			URI:         resourceURI(ws.Name),
+			Name:        ws.Name,
+			Title:       "Workspace " + ws.Name,
+			Description: "Registered workspace at " + ws.Path + " and its collections",
+			MimeType:    jsonMimeType,
+		})
+
+		cols, err := s.bruno.ListCollections(ws.Path)
+		if err != nil {
+			continue
+		}
+		for _, col := range cols {
+			resources = append(resources,
+				Resource{
+					URI:         resourceURI(ws.Name, col),
+					Name:        col,
+					Title:       "Collection " + col,
+					Description: "Requests and environments of the collection",
+					MimeType:    jsonMimeType,
+				},
+				Resource{
+					URI:      resourceURI(ws.Name, col, "bruno.json"),
+					Name:     col + "/bruno.json",
+					Title:    "bruno.json of " + col,
+					MimeType: jsonMimeType,
+				},
+			)
+
+			reqs, _ := s.bruno.ListRequests(ws.Path, col)
+			for _, r := range reqs {
+				resources = append(resources, Resource{
+					URI:      resourceURI(ws.Name, col, r),
+					Name:     col + "/" + r,
+					MimeType: bruMimeType,
+				})
+			}
+			envs, _ := s.bruno.ListEnvironments(ws.Path, col)
+			for _, e := range envs {
+				resources = append(resources, Resource{
+					URI:         resourceURI(ws.Name, col, "environments/"+e+".bru"),
+					Name:        col + "/environments/" + e + ".bru",
+					Description: "Environment " + e,
+					MimeType:    bruMimeType,
+				})
+			}
+		}
+	}
+	return map[string]any{"resources": resources}, nil
+}
+
+func (s *Server) handleResourceTemplatesList(ctx context.Context, req Request) (any, *RPCError) {
+	return map[string]any{
+		"resourceTemplates": []ResourceTemplate{
+			{
+				URITemplate: resourceScheme + "{workspace}",
+				Name:        "workspace",
+				Description: "A registered workspace and the collections in it",
+				MimeType:    jsonMimeType,
+			},
+			{
+				URITemplate: resourceScheme + "{workspace}/{collection}",
+				Name:        "collection",
+				Description: "The requests and environments of a collection",
+				MimeType:    jsonMimeType,
+			},
+			{
+				URITemplate: resourceScheme + "{workspace}/{collection}/bruno.json",
+				Name:        "collection-config",
+				Description: "The bruno.json of a collection",
+				MimeType:    jsonMimeType,
+			},
+			{
+				URITemplate: resourceScheme + "{workspace}/{collection}/{+path}",
+				Name:        "bru-file",
+				Description: "A .bru file of a collection: a request, folder.bru, collection.bru or environments/<name>.bru",
+				MimeType:    bruMimeType,
+			},
+		},
+	}, nil
+}
+
+func (s *Server) handleResourcesRead(ctx context.Context, req Request) (any, *RPCError) {
+	params, rpcErr := decodeParams[ResourceReadParams](req)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	wsName, col, rel, ok := parseResourceURI(params.URI)
+	if !ok {
+		return nil, NewError(CodeInvalidParams, "Invalid params: not a bruno:// resource URI: "+params.URI)
+	}
+
+	contents, rpcErr := s.resourceContents(wsName, col, rel, params.URI)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return map[string]any{"contents": []ResourceContents{contents}}, nil
+}
+
+// resourceContents reads a resource with the collection's secrets masked.
+func (s *Server) resourceContents(wsName, col, rel, uri string) (ResourceContents, *RPCError) {
+	contents, rpcErr := s.readResource(wsName, col, rel, uri)
+	if rpcErr != nil {
+		return contents, s.redactError(rpcErr)
+	}
+	s.collectSecrets(map[string]any{"workspace": wsName, "collection": col})
+	contents.Text = s.secrets.Redact(contents.Text)
+	return contents, nil
+}
+
+func (s *Server) readResource(wsName, col, rel, uri string) (ResourceContents, *RPCError) {
+	out := ResourceContents{URI: uri, MimeType: jsonMimeType}
+
+	ws, err := s.registry.Get(wsName)
+	if err != nil {
+		return out, resourceError(err, uri)
+	}
+
+	var v any
+	switch {
+	case col == "":
+		cols, err := s.bruno.ListCollections(ws.Path)
+		if err != nil {
+			return out, resourceError(err, uri)
+		}
+		v = map[string]any{"name": ws.Name, "path": ws.Path, "collections": cols}
+
+	case rel == "":
+		reqs, err := s.bruno.ListRequests(ws.Path, col)
+		if err != nil {
+			return out, resourceError(err, uri)
+		}
+		envs, err := s.bruno.ListEnvironments(ws.Path, col)
+		if err != nil {
+			return out, resourceError(err, uri)
+		}
+		v = map[string]any{"workspace": ws.Name, "name": col, "requests": reqs, "environments": envs}
+
+	default:
+		src, err := s.bruno.ReadCollectionFile(ws.Path, col, rel)
+		if err != nil {
+			return out, resourceError(err, uri)
+		}
+		if rel != "bruno.json" {
+			out.MimeType = bruMimeType
+		}
+		out.Text = string(src)
+		return out, nil
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return out, NewError(CodeInternalError, err.Error())
+	}
+	out.Text = string(b)
+	return out, nil
+}
+
+// resourceError reports missing workspaces, collections and files as
+// "resource not found"; anything else maps like the equivalent tool error.
+func resourceError(err error, uri string) *RPCError {
+	switch {
+	case errors.Is(err, workspace.ErrNotFound),
+		errors.Is(err, bruno.ErrCollectionMissing),
+		errors.Is(err, bruno.ErrNotACollection),
+		errors.Is(err, bruno.ErrRequestNotFound):
+		return NewErrorWithData(CodeResourceNotFound, "Resource not found", map[string]any{"uri": uri})
+	case errors.Is(err, workspace.ErrInvalidName), errors.Is(err, workspace.ErrInvalidPath):
+		return workspaceToRPCError(err)
+	default:
+		return brunoToRPCError(err)
+	}
+}
@@ -0,0 +1,138 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// dispatchMethod sends a request through dispatch and decodes the result into out.
+func dispatchMethod(t *testing.T, s *Server, method string, params any, out any) *RPCError {
+	t.Helper()
+	req := Request{JSONRPC: VERSION, ID: json.RawMessage("1"), Method: method}
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			t.Fatalf("marshal params: %v", err)
+		}
+		p := json.RawMessage(b)
+		req.Params = &p
+	}
+	res, rpcErr := s.dispatch(context.Background(), req)
+	if rpcErr != nil {
+		return rpcErr
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	return nil
+}
+
+func TestResources(t *testing.T) {
+	s := newWorkspaceServer(t)
+	if _, rpcErr := callTool(t, s, "requests.create", map[string]any{
+		"workspace": "ws", "collection": "api", "path": "users/get user", "method": "GET", "url": "{{host}}/users",
+	}); rpcErr != nil {
+		t.Fatalf("requests.create: %+v", rpcErr)
+	}
+	if _, rpcErr := callTool(t, s, "environments.create", map[string]any{
+		"workspace": "ws", "collection": "api", "environment": "dev",
+		"variables": []any{map[string]any{"name": "host", "value": "http://localhost"}},
+	}); rpcErr != nil {
+		t.Fatalf("environments.create: %+v", rpcErr)
+	}
+
+	var list struct {
+		Resources []Resource `json:"resources"`
+	}
+	if rpcErr := dispatchMethod(t, s, "resources/list", nil, &list); rpcErr != nil {
+		t.Fatalf("resources/list: %+v", rpcErr)
+	}
+	uris := map[string]Resource{}
+	for _, r := range list.Resources {
+		uris[r.URI] = r
+	}
+	for _, want := range []string{
+		"bruno://ws",
+		"bruno://ws/api",
+		"bruno://ws/api/bruno.json",
+		"bruno://ws/api/users/get%20user.bru",
+		"bruno://ws/api/environments/dev.bru",
+	} {
+		if _, ok := uris[want]; !ok {
+			t.Fatalf("expected %s in resources/list, got %+v", want, list.Resources)
+		}
+	}
+	if uris["bruno://ws/api/users/get%20user.bru"].MimeType != bruMimeType {
+		t.Fatalf("expected .bru mime type, got %+v", uris["bruno://ws/api/users/get%20user.bru"])
+	}
+
+	var read struct {
+		Contents []ResourceContents `json:"contents"`
+	}
+	if rpcErr := dispatchMethod(t, s, "resources/read", map[string]any{"uri": "bruno://ws/api/users/get%20user.bru"}, &read); rpcErr != nil {
+		t.Fatalf("resources/read: %+v", rpcErr)
+	}
+	if len(read.Contents) != 1 || read.Contents[0].MimeType != bruMimeType || !strings.Contains(read.Contents[0].Text, "{{host}}/users") {
+		t.Fatalf("unexpected request contents: %+v", read.Contents)
+	}
+
+	if rpcErr := dispatchMethod(t, s, "resources/read", map[string]any{"uri": "bruno://ws/api"}, &read); rpcErr != nil {
+		t.Fatalf("resources/read collection: %+v", rpcErr)
+	}
+	var col map[string]any
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &col); err != nil || read.Contents[0].MimeType != jsonMimeType {
+		t.Fatalf("expected JSON collection contents, got %+v (%v)", read.Contents, err)
+	}
+	if envs, _ := col["environments"].([]any); len(envs) != 1 || envs[0] != "dev" {
+		t.Fatalf("unexpected collection contents: %v", col)
+	}
+
+	for _, uri := range []string{"bruno://nope", "bruno://ws/missing", "bruno://ws/api/users/missing.bru"} {
+		rpcErr := dispatchMethod(t, s, "resources/read", map[string]any{"uri": uri}, &read)
+		if rpcErr == nil || rpcErr.Code != CodeResourceNotFound {
+			t.Fatalf("%s: expected resource not found, got %+v", uri, rpcErr)
+		}
+	}
+	for _, uri := range []string{"file:///etc/passwd", "bruno://ws/api/../../secret.bru", "bruno://ws/api/.git/config"} {
+		if rpcErr := dispatchMethod(t, s, "resources/read", map[string]any{"uri": uri}, &read); rpcErr == nil {
+			t.Fatalf("%s: expected an error", uri)
+		}
+	}
+
+	var templates struct {
+		ResourceTemplates []ResourceTemplate `json:"resourceTemplates"`
+	}
+	if rpcErr := dispatchMethod(t, s, "resources/templates/list", nil, &templates); rpcErr != nil || len(templates.ResourceTemplates) == 0 {
+		t.Fatalf("resources/templates/list: %+v %+v", templates, rpcErr)
+	}
+}
+
+func TestResourcesRead_RedactsSecrets(t *testing.T) {
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", ".env"), []byte("TOKEN=resource-secret\n"), 0o644); err != nil {
+		t.Fatalf("write .env: %v", err)
+	}
+	src := "meta {\n  name: leak\n  type: http\n  seq: 1\n}\n\nget {\n  url: http://localhost/?t=resource-secret\n}\n"
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", "leak.bru"), []byte(src), 0o644); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+
+	var read struct {
+		Contents []ResourceContents `json:"contents"`
+	}
+	if rpcErr := dispatchMethod(t, s, "resources/read", map[string]any{"uri": "bruno://ws/api/leak.bru"}, &read); rpcErr != nil {
+		t.Fatalf("resources/read: %+v", rpcErr)
+	}
+	if strings.Contains(read.Contents[0].Text, "resource-secret") {
+		t.Fatalf("expected secret masked, got %s", read.Contents[0].Text)
+	}
+}
@@ -0,0 +1,59 @@
+package mcp
+
+import "sync"
+
+// runtimeVars holds the runtime variables set by post-response vars, per
+// workspace and collection, for the lifetime of the server session.
+type runtimeVars struct {
+	mu sync.Mutex
+	m  map[string]map[string]string
+}
+
+func newRuntimeVars() *runtimeVars {
+	return &runtimeVars{m: make(map[string]map[string]string)}
+}
+
+func runtimeKey(workspace, collection string) string {
+	return workspace + "\x00" + collection
+}
+
+// merged returns the session's variables overlaid with explicit ones.
+func (r *runtimeVars) merged(workspace, collection string, explicit map[string]string) map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make(map[string]string, len(r.m[runtimeKey(workspace, collection)])+len(explicit))
+	for k, v := range r.m[runtimeKey(workspace, collection)] {
+		out[k] = v
+	}
+	for k, v := range explicit {
+		out[k] = v
+	}
+	return out
+}
+
+func (r *runtimeVars) get(workspace, collection string) map[string]string {
+	return r.merged(workspace, collection, nil)
+}
+
+func (r *runtimeVars) set(workspace, collection string, vars map[string]string) {
+	if len(vars) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := runtimeKey(workspace, collection)
+	if r.m[key] == nil {
+		r.m[key] = make(map[string]string)
+	}
+	for k, v := range vars {
+		r.m[key][k] = v
+	}
+}
+
+func (r *runtimeVars) clear(workspace, collection string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.m, runtimeKey(workspace, collection))
+}
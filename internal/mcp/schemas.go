@@ -0,0 +1,412 @@
+package mcp
+
+// Schemas shared by several tool definitions.
+
+var pairListSchema = map[string]any{
+	"type": "array",
+	"items": map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"key":      map[string]any{"type": "string"},
+			"value":    map[string]any{"type": "string"},
+			"disabled": map[string]any{"type": "boolean"},
+		},
+		"required": []string{"key"},
+	},
+}
+
+var authSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"mode": map[string]any{"type": "string"},
+		"basic": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"username": map[string]any{"type": "string"},
+				"password": map[string]any{"type": "string"},
+			},
+		},
+		"bearer": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"token": map[string]any{"type": "string"},
+			},
+		},
+		"digest": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"username": map[string]any{"type": "string"},
+				"password": map[string]any{"type": "string"},
+			},
+		},
+		"apikey": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"key":       map[string]any{"type": "string"},
+				"value":     map[string]any{"type": "string"},
+				"placement": map[string]any{"type": "string", "enum": []string{"header", "queryparams"}},
+			},
+		},
+		"other": map[string]any{
+			"type":                 "object",
+			"additionalProperties": pairListSchema,
+		},
+	},
+}
+
+var bodySchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"mode": map[string]any{
+			"type": "string",
+			"enum": []string{"none", "json", "text", "xml", "sparql", "formUrlEncoded", "multipartForm", "graphql"},
+		},
+		"json":           map[string]any{"type": "string"},
+		"text":           map[string]any{"type": "string"},
+		"xml":            map[string]any{"type": "string"},
+		"sparql":         map[string]any{"type": "string"},
+		"formUrlEncoded": pairListSchema,
+		"multipartForm":  pairListSchema,
+		"graphql": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query":     map[string]any{"type": "string"},
+				"variables": map[string]any{"type": "string"},
+			},
+		},
+	},
+}
+
+var requestSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"meta": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name": map[string]any{"type": "string"},
+				"type": map[string]any{"type": "string"},
+				"seq":  map[string]any{"type": "integer"},
+			},
+		},
+		"method":     map[string]any{"type": "string"},
+		"url":        map[string]any{"type": "string"},
+		"headers":    pairListSchema,
+		"query":      pairListSchema,
+		"pathParams": pairListSchema,
+		"body":       bodySchema,
+		"auth":       authSchema,
+		"vars":       varsSchema,
+		"assertions": pairListSchema,
+		"scripts":    scriptsSchema,
+		"tests":      map[string]any{"type": "string"},
+		"docs":       map[string]any{"type": "string"},
+	},
+}
+
+var stringListSchema = map[string]any{
+	"type":  "array",
+	"items": map[string]any{"type": "string"},
+}
+
+var requestPatchSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"name":                   map[string]any{"type": "string"},
+		"seq":                    map[string]any{"type": "integer"},
+		"method":                 map[string]any{"type": "string"},
+		"url":                    map[string]any{"type": "string"},
+		"setHeaders":             pairListSchema,
+		"removeHeaders":          stringListSchema,
+		"setQuery":               pairListSchema,
+		"removeQuery":            stringListSchema,
+		"setPathParams":          pairListSchema,
+		"removePathParams":       stringListSchema,
+		"body":                   bodySchema,
+		"auth":                   authSchema,
+		"setPreRequestVars":      pairListSchema,
+		"removePreRequestVars":   stringListSchema,
+		"setPostResponseVars":    pairListSchema,
+		"removePostResponseVars": stringListSchema,
+		"setAssertions":          pairListSchema,
+		"removeAssertions":       stringListSchema,
+		"preRequestScript":       map[string]any{"type": "string"},
+		"postResponseScript":     map[string]any{"type": "string"},
+		"tests":                  map[string]any{"type": "string"},
+		"docs":                   map[string]any{"type": "string"},
+	},
+	"additionalProperties": false,
+}
+
+var varsSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"preRequest":   pairListSchema,
+		"postResponse": pairListSchema,
+	},
+}
+
+var scriptsSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"preRequest":   map[string]any{"type": "string"},
+		"postResponse": map[string]any{"type": "string"},
+	},
+}
+
+var folderSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"path": map[string]any{"type": "string"},
+		"meta": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name": map[string]any{"type": "string"},
+				"seq":  map[string]any{"type": "integer"},
+			},
+		},
+		"headers": pairListSchema,
+		"auth":    authSchema,
+		"vars":    varsSchema,
+		"scripts": scriptsSchema,
+		"tests":   map[string]any{"type": "string"},
+		"docs":    map[string]any{"type": "string"},
+	},
+}
+
+var settingsPatchSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"name":                   map[string]any{"type": "string"},
+		"seq":                    map[string]any{"type": "integer"},
+		"setHeaders":             pairListSchema,
+		"removeHeaders":          stringListSchema,
+		"auth":                   authSchema,
+		"setPreRequestVars":      pairListSchema,
+		"removePreRequestVars":   stringListSchema,
+		"setPostResponseVars":    pairListSchema,
+		"removePostResponseVars": stringListSchema,
+		"preRequestScript":       map[string]any{"type": "string"},
+		"postResponseScript":     map[string]any{"type": "string"},
+		"tests":                  map[string]any{"type": "string"},
+		"docs":                   map[string]any{"type": "string"},
+	},
+	"additionalProperties": false,
+}
+
+var collectionSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"name":     map[string]any{"type": "string"},
+		"path":     map[string]any{"type": "string"},
+		"settings": folderSchema,
+		"config":   map[string]any{"type": "object"},
+	},
+}
+
+var envVarListSchema = map[string]any{
+	"type": "array",
+	"items": map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":     map[string]any{"type": "string"},
+			"value":    map[string]any{"type": "string"},
+			"disabled": map[string]any{"type": "boolean"},
+			"secret":   map[string]any{"type": "boolean"},
+		},
+		"required": []string{"name"},
+	},
+}
+
+var environmentSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"name":      map[string]any{"type": "string"},
+		"variables": envVarListSchema,
+	},
+}
+
+var variablesSchema = map[string]any{
+	"type":                 "object",
+	"additionalProperties": map[string]any{"type": "string"},
+}
+
+var httpHeadersSchema = map[string]any{
+	"type":                 "object",
+	"additionalProperties": stringListSchema,
+}
+
+var runResultSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"request": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"method":  map[string]any{"type": "string"},
+				"url":     map[string]any{"type": "string"},
+				"headers": httpHeadersSchema,
+				"body":    map[string]any{"type": "string"},
+			},
+		},
+		"status":       map[string]any{"type": "integer"},
+		"statusText":   map[string]any{"type": "string"},
+		"headers":      httpHeadersSchema,
+		"body":         map[string]any{"type": "string"},
+		"bodyEncoding": map[string]any{"type": "string", "enum": []string{"base64"}},
+		"size":         map[string]any{"type": "integer"},
+		"truncated":    map[string]any{"type": "boolean"},
+		"durationMs":   map[string]any{"type": "integer"},
+		"variables":    variablesSchema,
+		"assertions":   assertionResultsSchema,
+	},
+}
+
+var resolutionSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"input": map[string]any{"type": "string"},
+		"value": map[string]any{"type": "string"},
+		"variables": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name":     map[string]any{"type": "string"},
+					"value":    map[string]any{"type": "string"},
+					"source":   map[string]any{"type": "string"},
+					"folder":   map[string]any{"type": "string"},
+					"resolved": map[string]any{"type": "boolean"},
+				},
+			},
+		},
+	},
+}
+
+var assertionResultsSchema = map[string]any{
+	"type": "array",
+	"items": map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"lhsExpr":    map[string]any{"type": "string"},
+			"rhsExpr":    map[string]any{"type": "string"},
+			"operator":   map[string]any{"type": "string"},
+			"rhsOperand": map[string]any{"type": "string"},
+			"status":     map[string]any{"type": "string", "enum": []string{"pass", "fail"}},
+			"error":      map[string]any{"type": "string"},
+			"actual":     map[string]any{},
+		},
+	},
+}
+
+var runSummarySchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"totalRequests":    map[string]any{"type": "integer"},
+		"passedRequests":   map[string]any{"type": "integer"},
+		"failedRequests":   map[string]any{"type": "integer"},
+		"errorRequests":    map[string]any{"type": "integer"},
+		"totalAssertions":  map[string]any{"type": "integer"},
+		"passedAssertions": map[string]any{"type": "integer"},
+		"failedAssertions": map[string]any{"type": "integer"},
+	},
+}
+
+var collectionRunSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"collection":  map[string]any{"type": "string"},
+		"folder":      map[string]any{"type": "string"},
+		"environment": map[string]any{"type": "string"},
+		"results": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"path":     map[string]any{"type": "string"},
+					"name":     map[string]any{"type": "string"},
+					"response": runResultSchema,
+					"error":    map[string]any{"type": "string"},
+				},
+			},
+		},
+		"summary":    runSummarySchema,
+		"variables":  variablesSchema,
+		"bailed":     map[string]any{"type": "boolean"},
+		"durationMs": map[string]any{"type": "integer"},
+	},
+}
+
+var cliRunSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"collection":  map[string]any{"type": "string"},
+		"folder":      map[string]any{"type": "string"},
+		"environment": map[string]any{"type": "string"},
+		"summary":     map[string]any{"type": "object"},
+		"iterations": map[string]any{
+			"type":        "array",
+			"description": "The report written by bru run --reporter-json",
+			"items":       map[string]any{"type": "object"},
+		},
+		"exitCode":   map[string]any{"type": "integer"},
+		"stderr":     map[string]any{"type": "string"},
+		"durationMs": map[string]any{"type": "integer"},
+	},
+}
+
+var runSummaryEntrySchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"id":          map[string]any{"type": "string"},
+		"kind":        map[string]any{"type": "string"},
+		"collection":  map[string]any{"type": "string"},
+		"target":      map[string]any{"type": "string"},
+		"environment": map[string]any{"type": "string"},
+		"startedAt":   map[string]any{"type": "string", "format": "date-time"},
+		"durationMs":  map[string]any{"type": "integer"},
+		"passed":      map[string]any{"type": "boolean"},
+		"summary":     runSummarySchema,
+	},
+}
+
+var runEntrySchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"id":          map[string]any{"type": "string"},
+		"kind":        map[string]any{"type": "string"},
+		"collection":  map[string]any{"type": "string"},
+		"target":      map[string]any{"type": "string"},
+		"environment": map[string]any{"type": "string"},
+		"startedAt":   map[string]any{"type": "string", "format": "date-time"},
+		"durationMs":  map[string]any{"type": "integer"},
+		"passed":      map[string]any{"type": "boolean"},
+		"inputs":      map[string]any{"type": "object"},
+		"summary":     runSummarySchema,
+		"requests": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"path":      map[string]any{"type": "string"},
+					"iteration": map[string]any{"type": "integer"},
+					"request":   runResultSchema["properties"].(map[string]any)["request"],
+					"response": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"status":        map[string]any{"type": "integer"},
+							"statusText":    map[string]any{"type": "string"},
+							"headers":       httpHeadersSchema,
+							"size":          map[string]any{"type": "integer"},
+							"body":          map[string]any{"type": "string"},
+							"bodyEncoding":  map[string]any{"type": "string"},
+							"bodyTruncated": map[string]any{"type": "boolean"},
+							"durationMs":    map[string]any{"type": "integer"},
+						},
+					},
+					"error":      map[string]any{"type": "string"},
+					"assertions": assertionResultsSchema,
+					"variables":  variablesSchema,
+				},
+			},
+		},
+	},
+}
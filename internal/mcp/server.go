@@ -17,15 +17,26 @@ type Server struct {
 	stderr   io.Writer
 	registry *workspace.Registry
 	bruno    *bruno.Client
+	secrets  *bruno.Redactor
+	runtime  *runtimeVars
+	session  session
+	out      outbox
+	subs     subscriptions
+	watcher  *workspace.Watcher
 }
 
 func NewServer() *Server {
-	return &Server{
+	s := &Server{
 		handlers: make(map[string]HandlerFunc),
 		stderr:   os.Stderr,
 		registry: workspace.NewRegistry(),
 		bruno:    bruno.NewClient(),
+		secrets:  bruno.NewRedactor(),
+		runtime:  newRuntimeVars(),
 	}
+	s.bruno.MaskResolvedSecrets(s.secrets)
+	s.watcher = workspace.NewWatcher(s.registry, watchInterval)
+	return s
 }
 
 func (s *Server) Handle(method string, h HandlerFunc) {
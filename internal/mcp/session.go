@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"sync"
+)
+
+// supportedProtocolVersions lists the MCP revisions the server speaks,
+// newest first.
+var supportedProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// negotiateProtocolVersion returns the client's version when the server
+// supports it and the newest supported version otherwise, leaving the client
+// to disconnect if it cannot use that.
+func negotiateProtocolVersion(requested string) string {
+	for _, v := range supportedProtocolVersions {
+		if v == requested {
+			return v
+		}
+	}
+	return supportedProtocolVersions[0]
+}
+
+type Implementation struct {
+	Name    string `json:"name"`
+	Title   string `json:"title,omitempty"`
+	Version string `json:"version"`
+}
+
+// ClientCapabilities is what the client declared in initialize.
+type ClientCapabilities struct {
+	Roots *struct {
+		ListChanged bool `json:"listChanged,omitempty"`
+	} `json:"roots,omitempty"`
+	Sampling     map[string]any `json:"sampling,omitempty"`
+	Elicitation  map[string]any `json:"elicitation,omitempty"`
+	Experimental map[string]any `json:"experimental,omitempty"`
+}
+
+const (
+	sessionNew = iota
+	sessionInitializing
+	sessionReady
+)
+
+// session tracks the initialize handshake of the connected client.
+type session struct {
+	mu              sync.Mutex
+	state           int
+	protocolVersion string
+	clientInfo      Implementation
+	clientCaps      ClientCapabilities
+}
+
+// admit reports whether a request may be handled in the current state:
+// before notifications/initialized only initialize and ping are served.
+func (ss *session) admit(req Request) *RPCError {
+	if req.IsNotification() {
+		return nil
+	}
+	switch req.Method {
+	case "initialize", "ping":
+		return nil
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.state != sessionReady {
+		return NewError(CodeInvalidRequest, "Server not initialized: send initialize and notifications/initialized first")
+	}
+	return nil
+}
+
+func (ss *session) initialize(p InitializeParams) (string, *RPCError) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.state == sessionReady {
+		return "", NewError(CodeInvalidRequest, "Invalid request: already initialized")
+	}
+	ss.state = sessionInitializing
+	ss.protocolVersion = negotiateProtocolVersion(p.ProtocolVersion)
+	ss.clientInfo = p.ClientInfo
+	ss.clientCaps = p.Capabilities
+	return ss.protocolVersion, nil
+}
+
+func (ss *session) initialized() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.state == sessionInitializing {
+		ss.state = sessionReady
+	}
+}
+
+func (ss *session) ready() bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.state == sessionReady
+}
+
+// ClientCapabilities returns what the client declared in initialize.
+func (s *Server) ClientCapabilities() ClientCapabilities {
+	s.session.mu.Lock()
+	defer s.session.mu.Unlock()
+	return s.session.clientCaps
+}
+
+// ProtocolVersion returns the negotiated protocol version, or "" before
+// initialize.
+func (s *Server) ProtocolVersion() string {
+	s.session.mu.Lock()
+	defer s.session.mu.Unlock()
+	return s.session.protocolVersion
+}
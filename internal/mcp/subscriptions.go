@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/workspace"
+)
+
+// watchInterval is how often the workspaces are polled for changes.
+const watchInterval = 2 * time.Second
+
+// outbox serializes messages onto the transport, so that notifications from
+// the watcher do not interleave with responses.
+type outbox struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+func (o *outbox) attach(w *bufio.Writer) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.w = w
+}
+
+// send writes and flushes one message; without a transport it is dropped.
+func (o *outbox) send(v any) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.w == nil {
+		return nil
+	}
+	if err := writeMessage(o.w, v); err != nil {
+		return err
+	}
+	return o.w.Flush()
+}
+
+func (s *Server) notify(method string, params any) {
+	if err := s.out.send(Notification{JSONRPC: VERSION, Method: method, Params: params}); err != nil {
+		fmt.Fprintf(s.stderr, "send %s: %v\n", method, err)
+	}
+}
+
+// subscriptions is the set of resource URIs the client subscribed to.
+type subscriptions struct {
+	mu   sync.Mutex
+	uris map[string]bool
+}
+
+func (ss *subscriptions) add(uri string) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.uris == nil {
+		ss.uris = make(map[string]bool)
+	}
+	ss.uris[uri] = true
+}
+
+func (ss *subscriptions) remove(uri string) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	delete(ss.uris, uri)
+}
+
+func (ss *subscriptions) has(uri string) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.uris[uri]
+}
+
+func (s *Server) handleResourcesSubscribe(ctx context.Context, req Request) (any, *RPCError) {
+	uri, rpcErr := s.subscriptionURI(req)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	s.subs.add(uri)
+	return map[string]any{}, nil
+}
+
+func (s *Server) handleResourcesUnsubscribe(ctx context.Context, req Request) (any, *RPCError) {
+	uri, rpcErr := s.subscriptionURI(req)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	s.subs.remove(uri)
+	return map[string]any{}, nil
+}
+
+// subscriptionURI validates the uri param. Files that do not exist yet may be
+// subscribed to, to learn when they are created; the workspace must exist.
+func (s *Server) subscriptionURI(req Request) (string, *RPCError) {
+	params, rpcErr := decodeParams[ResourceReadParams](req)
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+	wsName, col, rel, ok := parseResourceURI(params.URI)
+	if !ok {
+		return "", NewError(CodeInvalidParams, "Invalid params: not a bruno:// resource URI: "+params.URI)
+	}
+	if _, err := s.registry.Get(wsName); err != nil {
+		return "", resourceError(err, params.URI)
+	}
+	// Normalize the spelling so notifications match the subscription.
+	return resourceURI(wsName, col, rel), nil
+}
+
+// notifyChanges turns a batch of watcher changes into resource notifications.
+// Files outside any collection are not resources and are ignored. A created
+// or deleted file also updates its collection and workspace resources, whose
+// contents list it, and changes the resource list.
+func (s *Server) notifyChanges(changes []workspace.Change) {
+	if !s.session.ready() {
+		return
+	}
+
+	sent := make(map[string]bool)
+	listChanged := false
+	for _, c := range changes {
+		ws, err := s.registry.Get(c.Workspace)
+		if err != nil {
+			continue
+		}
+		col, rel, ok := s.bruno.CollectionOf(ws.Path, c.Path)
+		if !ok {
+			continue
+		}
+		uris := []string{resourceURI(c.Workspace, col, rel)}
+		if c.Op != workspace.Modified {
+			listChanged = true
+			uris = append(uris, resourceURI(c.Workspace, col), resourceURI(c.Workspace))
+		}
+		for _, uri := range uris {
+			if sent[uri] || !s.subs.has(uri) {
+				continue
+			}
+			sent[uri] = true
+			s.notify("notifications/resources/updated", map[string]any{"uri": uri})
+		}
+	}
+	if listChanged {
+		s.notify("notifications/resources/list_changed", nil)
+	}
+}
@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/workspace"
+)
+
+// readyServer returns a workspace server past the handshake whose
+// notifications are written to the returned buffer.
+func readyServer(t *testing.T) (*Server, *bytes.Buffer) {
+	t.Helper()
+	s := newWorkspaceServer(t)
+	if _, rpcErr := s.session.initialize(InitializeParams{ProtocolVersion: supportedProtocolVersions[0]}); rpcErr != nil {
+		t.Fatalf("initialize: %+v", rpcErr)
+	}
+	s.session.initialized()
+
+	var buf bytes.Buffer
+	s.out.attach(bufio.NewWriter(&buf))
+	return s, &buf
+}
+
+func notifications(t *testing.T, buf *bytes.Buffer) []Notification {
+	t.Helper()
+	var out []Notification
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var n Notification
+		if err := json.Unmarshal([]byte(line), &n); err != nil {
+			t.Fatalf("decode notification %q: %v", line, err)
+		}
+		out = append(out, n)
+	}
+	buf.Reset()
+	return out
+}
+
+func TestWatcherPoll(t *testing.T) {
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	req := filepath.Join(ws.Path, "api", "ping.bru")
+	if err := os.WriteFile(req, []byte("meta {\n  name: ping\n}\n"), 0o644); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	if changes := s.watcher.Poll(); len(changes) != 0 {
+		t.Fatalf("expected the first poll to only record state, got %+v", changes)
+	}
+
+	if err := os.WriteFile(req, []byte("meta {\n  name: ping\n  seq: 2\n}\n"), 0o644); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", "notes.txt"), []byte("ignored"), 0o644); err != nil {
+		t.Fatalf("write notes: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", "pong.bru"), []byte("meta {}\n"), 0o644); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	changes := s.watcher.Poll()
+	if len(changes) != 2 ||
+		changes[0] != (workspace.Change{Workspace: "ws", Path: "api/ping.bru", Op: workspace.Modified}) ||
+		changes[1] != (workspace.Change{Workspace: "ws", Path: "api/pong.bru", Op: workspace.Created}) {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	if err := os.Remove(req); err != nil {
+		t.Fatalf("remove request: %v", err)
+	}
+	changes = s.watcher.Poll()
+	if len(changes) != 1 || changes[0].Op != workspace.Deleted || changes[0].Path != "api/ping.bru" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestResourceSubscriptions(t *testing.T) {
+	s, buf := readyServer(t)
+	ws, _ := s.registry.Get("ws")
+	req := filepath.Join(ws.Path, "api", "ping.bru")
+	if err := os.WriteFile(req, []byte("meta {\n  name: ping\n}\n"), 0o644); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	s.watcher.Poll()
+
+	var empty map[string]any
+	for _, uri := range []string{"bruno://ws/api/ping.bru", "bruno://ws/api", "bruno://ws/api/users/new.bru"} {
+		if rpcErr := dispatchMethod(t, s, "resources/subscribe", map[string]any{"uri": uri}, &empty); rpcErr != nil {
+			t.Fatalf("subscribe %s: %+v", uri, rpcErr)
+		}
+	}
+	if rpcErr := dispatchMethod(t, s, "resources/subscribe", map[string]any{"uri": "bruno://nope/api"}, &empty); rpcErr == nil || rpcErr.Code != CodeResourceNotFound {
+		t.Fatalf("expected resource not found for an unknown workspace, got %+v", rpcErr)
+	}
+
+	// A modification updates the file only; the resource list is unchanged.
+	if err := os.WriteFile(req, []byte("meta {\n  name: ping\n  seq: 2\n}\n"), 0o644); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	s.notifyChanges(s.watcher.Poll())
+	got := notifications(t, buf)
+	if len(got) != 1 || got[0].Method != "notifications/resources/updated" || got[0].Params.(map[string]any)["uri"] != "bruno://ws/api/ping.bru" {
+		t.Fatalf("unexpected notifications: %+v", got)
+	}
+
+	// A created file updates itself and its collection, and changes the list.
+	if err := os.MkdirAll(filepath.Join(ws.Path, "api", "users"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(ws.Path, "api", "users", "new.bru"), []byte("meta {}\n"), 0o644); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	s.notifyChanges(s.watcher.Poll())
+	got = notifications(t, buf)
+	var methods []string
+	for _, n := range got {
+		m := n.Method
+		if p, ok := n.Params.(map[string]any); ok {
+			m += " " + p["uri"].(string)
+		}
+		methods = append(methods, m)
+	}
+	want := []string{
+		"notifications/resources/updated bruno://ws/api/users/new.bru",
+		"notifications/resources/updated bruno://ws/api",
+		"notifications/resources/list_changed",
+	}
+	if strings.Join(methods, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected notifications:\n%s", strings.Join(methods, "\n"))
+	}
+
+	// After unsubscribing only the list change is reported.
+	for _, uri := range []string{"bruno://ws/api/ping.bru", "bruno://ws/api"} {
+		if rpcErr := dispatchMethod(t, s, "resources/unsubscribe", map[string]any{"uri": uri}, &empty); rpcErr != nil {
+			t.Fatalf("unsubscribe %s: %+v", uri, rpcErr)
+		}
+	}
+	if err := os.Remove(req); err != nil {
+		t.Fatalf("remove request: %v", err)
+	}
+	s.notifyChanges(s.watcher.Poll())
+	got = notifications(t, buf)
+	if len(got) != 1 || got[0].Method != "notifications/resources/list_changed" {
+		t.Fatalf("unexpected notifications: %+v", got)
+	}
+}
+
+func TestResourceSubscriptions_WorkspaceIsCollection(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "api")
+	if err := os.MkdirAll(filepath.Join(dir, "users"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bruno.json"), []byte(`{"version":"1","name":"api","type":"collection"}`), 0o644); err != nil {
+		t.Fatalf("write bruno.json: %v", err)
+	}
+	s := NewServer()
+	s.RegisterCoreMethods()
+	if _, rpcErr := callTool(t, s, "workspace.register", map[string]any{"name": "ws", "path": dir}); rpcErr != nil {
+		t.Fatalf("workspace.register: %+v", rpcErr)
+	}
+	if _, rpcErr := s.session.initialize(InitializeParams{}); rpcErr != nil {
+		t.Fatalf("initialize: %+v", rpcErr)
+	}
+	s.session.initialized()
+	var buf bytes.Buffer
+	s.out.attach(bufio.NewWriter(&buf))
+	s.watcher.Poll()
+
+	var empty map[string]any
+	for _, uri := range []string{"bruno://ws/api", "bruno://ws/api/users/get.bru", "bruno://ws/users"} {
+		if rpcErr := dispatchMethod(t, s, "resources/subscribe", map[string]any{"uri": uri}, &empty); rpcErr != nil {
+			t.Fatalf("subscribe %s: %+v", uri, rpcErr)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "users", "get.bru"), []byte("meta {}\n"), 0o644); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	s.notifyChanges(s.watcher.Poll())
+
+	var got []string
+	for _, n := range notifications(t, &buf) {
+		m := n.Method
+		if p, ok := n.Params.(map[string]any); ok {
+			m += " " + p["uri"].(string)
+		}
+		got = append(got, m)
+	}
+	want := []string{
+		"notifications/resources/updated bruno://ws/api/users/get.bru",
+		"notifications/resources/updated bruno://ws/api",
+		"notifications/resources/list_changed",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected notifications:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestResourceNotificationsWaitForHandshake(t *testing.T) {
+	s := newWorkspaceServer(t)
+	var buf bytes.Buffer
+	s.out.attach(bufio.NewWriter(&buf))
+
+	s.notifyChanges([]workspace.Change{{Workspace: "ws", Path: "api/ping.bru", Op: workspace.Created}})
+	if buf.Len() != 0 {
+		t.Fatalf("expected no notifications before initialization, got %s", buf.String())
+	}
+}
@@ -0,0 +1,27 @@
+package mcp
+
+import (
+	"encoding/json"
+)
+
+// newToolResult wraps what a tool returned. A successful result goes into
+// structuredContent and, serialised, into a text block for clients that do
+// not read structured output; an error becomes an isError result carrying
+// its message.
+func newToolResult(res any, rpcErr *RPCError) (*ToolResult, *RPCError) {
+	if rpcErr != nil {
+		return &ToolResult{
+			Content: []Content{{Type: "text", Text: rpcErr.Message}},
+			IsError: true,
+		}, nil
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return nil, NewError(CodeInternalError, "encode tool result: "+err.Error())
+	}
+	return &ToolResult{
+		Content:           []Content{{Type: "text", Text: string(b)}},
+		StructuredContent: json.RawMessage(b),
+	}, nil
+}
@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// dispatchTool calls a tool through tools/call and decodes the wrapped result.
+func dispatchTool(t *testing.T, s *Server, name string, args map[string]any) ToolResult {
+	t.Helper()
+	b, err := json.Marshal(map[string]any{"name": name, "arguments": args})
+	if err != nil {
+		t.Fatalf("marshal tool params: %v", err)
+	}
+	p := json.RawMessage(b)
+	res, rpcErr := s.dispatch(context.Background(), Request{JSONRPC: VERSION, ID: json.RawMessage("1"), Method: "tools/call", Params: &p})
+	if rpcErr != nil {
+		t.Fatalf("%s: unexpected protocol error %+v", name, rpcErr)
+	}
+	b, err = json.Marshal(res)
+	if err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	var out ToolResult
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	return out
+}
+
+func TestToolsCall_ResultWrapping(t *testing.T) {
+	s := newWorkspaceServer(t)
+
+	res := dispatchTool(t, s, "collections.list", map[string]any{"workspace": "ws"})
+	if res.IsError || len(res.Content) != 1 || res.Content[0].Type != "text" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	var fromText map[string]any
+	if err := json.Unmarshal([]byte(res.Content[0].Text), &fromText); err != nil {
+		t.Fatalf("expected JSON text content: %v", err)
+	}
+	b, _ := json.Marshal(res.StructuredContent)
+	if string(b) != res.Content[0].Text || !strings.Contains(string(b), `"collections":["api"]`) {
+		t.Fatalf("expected structured content to match text, got %s and %s", b, res.Content[0].Text)
+	}
+
+	res = dispatchTool(t, s, "requests.get", map[string]any{"workspace": "ws", "collection": "api", "path": "missing"})
+	if !res.IsError || res.StructuredContent != nil || !strings.Contains(res.Content[0].Text, "request not found") {
+		t.Fatalf("expected in-band tool error, got %+v", res)
+	}
+	res = dispatchTool(t, s, "collections.list", map[string]any{"workspace": "nope"})
+	if !res.IsError || !strings.Contains(res.Content[0].Text, "workspace not found") {
+		t.Fatalf("expected in-band workspace error, got %+v", res)
+	}
+}
+
+func TestToolsCall_StructuredContentMatchesOutputSchema(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+
+	s := newWorkspaceServer(t)
+	ws, _ := s.registry.Get("ws")
+	if err := os.WriteFile(filepath.Join(ws.Path, "ids.csv"), []byte("id\n1\n"), 0o644); err != nil {
+		t.Fatalf("write data: %v", err)
+	}
+
+	if p := checkSchema("probe", map[string]any{"type": "object", "properties": map[string]any{"a": map[string]any{"type": "string"}}}, map[string]any{"a": 1.0, "b": true}); len(p) != 2 {
+		t.Fatalf("expected the schema check to catch both problems, got %v", p)
+	}
+
+	list, _ := s.handleToolList(context.Background(), Request{})
+	schemas := map[string]map[string]any{}
+	for _, tool := range list.(map[string]any)["tools"].([]any) {
+		tool := tool.(map[string]any)
+		schemas[tool["name"].(string)], _ = tool["outputSchema"].(map[string]any)
+	}
+
+	target := map[string]any{"workspace": "ws", "collection": "api"}
+	with := func(kv ...any) map[string]any {
+		m := map[string]any{}
+		for k, v := range target {
+			m[k] = v
+		}
+		for i := 0; i < len(kv); i += 2 {
+			m[kv[i].(string)] = kv[i+1]
+		}
+		return m
+	}
+	calls := []struct {
+		name string
+		args map[string]any
+	}{
+		{"workspace.get", map[string]any{"name": "ws"}},
+		{"workspace.list", map[string]any{}},
+		{"collections.list", map[string]any{"workspace": "ws"}},
+		{"collections.get", target},
+		{"collections.update", with("config", map[string]any{"presets": map[string]any{"requestUrl": srv.URL}})},
+		{"folders.create", with("path", "users")},
+		{"folders.update", with("path", "users", "patch", map[string]any{"docs": "Users"})},
+		{"folders.get", with("path", "users")},
+		{"folders.list", target},
+		{"requests.create", with("path", "users/get", "method", "get", "url", srv.URL+"/users/{{id}}")},
+		{"requests.update", with("path", "users/get", "patch", map[string]any{
+			"setAssertions":       []any{map[string]any{"key": "res.status", "value": "eq 200"}},
+			"setPostResponseVars": []any{map[string]any{"key": "userId", "value": "res.body.id"}},
+		})},
+		{"requests.get", with("path", "users/get")},
+		{"requests.list", target},
+		{"requests.duplicate", with("path", "users/get", "to", "users/copy")},
+		{"requests.move", with("path", "users/copy", "to", "users/moved")},
+		{"requests.delete", with("path", "users/moved")},
+		{"environments.create", with("environment", "dev", "variables", []any{map[string]any{"name": "id", "value": "1"}})},
+		{"environments.update", with("environment", "dev", "set", []any{map[string]any{"name": "host", "value": srv.URL}})},
+		{"environments.get", with("environment", "dev")},
+		{"environments.list", target},
+		{"variables.resolve", with("path", "users/get", "environment", "dev")},
+		{"requests.run", with("path", "users/get", "environment", "dev", "reports", map[string]any{"json": "out/run.json"})},
+		{"requests.assert", with("path", "users/get", "environment", "dev")},
+		{"collections.run", with("environment", "dev")},
+		{"collections.iterate", with("dataFile", "ids.csv")},
+		{"variables.runtime", target},
+		{"runs.list", map[string]any{"workspace": "ws"}},
+		{"bruno.info", map[string]any{}},
+		{"environments.delete", with("environment", "dev")},
+		{"folders.delete", with("path", "users", "recursive", true)},
+		{"runs.delete", map[string]any{"workspace": "ws", "all": true}},
+	}
+	for _, c := range calls {
+		res := dispatchTool(t, s, c.name, c.args)
+		if res.IsError {
+			t.Fatalf("%s: %s", c.name, res.Content[0].Text)
+		}
+		schema, ok := schemas[c.name]
+		if !ok {
+			t.Fatalf("%s: no output schema", c.name)
+		}
+		for _, problem := range checkSchema(c.name, schema, res.StructuredContent) {
+			t.Errorf("%s", problem)
+		}
+	}
+}
+
+// checkSchema validates v against the subset of JSON Schema the tool schemas
+// use. Object properties missing from the schema are reported too, so that
+// schemas stay complete.
+func checkSchema(path string, schema map[string]any, v any) []string {
+	if v == nil {
+		return nil
+	}
+	var problems []string
+	typ, _ := schema["type"].(string)
+	switch typ {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected object, got %T", path, v)}
+		}
+		props, _ := schema["properties"].(map[string]any)
+		extra, _ := schema["additionalProperties"].(map[string]any)
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if ps, ok := props[k].(map[string]any); ok {
+				problems = append(problems, checkSchema(path+"."+k, ps, obj[k])...)
+			} else if extra != nil {
+				problems = append(problems, checkSchema(path+"."+k, extra, obj[k])...)
+			} else if props != nil && len(props) > 0 {
+				problems = append(problems, fmt.Sprintf("%s.%s: not in output schema", path, k))
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected array, got %T", path, v)}
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, e := range arr {
+				problems = append(problems, checkSchema(fmt.Sprintf("%s[%d]", path, i), items, e)...)
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			problems = append(problems, fmt.Sprintf("%s: expected string, got %T", path, v))
+		}
+	case "integer":
+		if f, ok := v.(float64); !ok || f != float64(int64(f)) {
+			problems = append(problems, fmt.Sprintf("%s: expected integer, got %v", path, v))
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			problems = append(problems, fmt.Sprintf("%s: expected number, got %T", path, v))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			problems = append(problems, fmt.Sprintf("%s: expected boolean, got %T", path, v))
+		}
+	}
+	return problems
+}
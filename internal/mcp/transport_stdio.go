@@ -19,8 +19,16 @@ func (s *Server) ServeStdio(ctx context.Context) error {
 	buf := make([]byte, initialScanBuf)
 	in.Buffer(buf, maxScanBuf)
 
-	out := bufio.NewWriter(os.Stdout)
-	defer out.Flush()
+	s.out.attach(bufio.NewWriter(os.Stdout))
+	defer s.out.attach(nil)
+
+	// The watcher stops with the transport; its notifications share the outbox.
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	defer stopWatch()
+	go s.watcher.Run(watchCtx, s.notifyChanges)
+
+	// Probe bru in the background so that initialize does not wait for it.
+	go s.bruno.CLIInfo()
 
 	for in.Scan() {
 		line := in.Bytes()
@@ -31,16 +39,20 @@ func (s *Server) ServeStdio(ctx context.Context) error {
 		req, rpcError := parseAndValidateRequest(line)
 		if rpcError != nil {
 			if !req.IsNotification() {
-				_ = writeResponse(out, Response{
+				_ = s.out.send(Response{
 					JSONRPC: VERSION,
 					ID:      req.ID,
 					Error:   rpcError,
 				})
-				_ = out.Flush()
 			}
 			continue
 		}
 
+		if rpcError := s.session.admit(req); rpcError != nil {
+			_ = s.out.send(Response{JSONRPC: VERSION, ID: req.ID, Error: rpcError})
+			continue
+		}
+
 		result, errObj := s.dispatch(ctx, req)
 		if req.IsNotification() {
 			continue
@@ -57,11 +69,9 @@ func (s *Server) ServeStdio(ctx context.Context) error {
 			resp.Result = result
 		}
 
-		if err := writeResponse(out, resp); err != nil {
+		if err := s.out.send(resp); err != nil {
 			return err
 		}
-
-		_ = out.Flush()
 	}
 
 	if err := in.Err(); err != nil {
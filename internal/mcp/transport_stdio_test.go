@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
 )
 
 var stdioMu sync.Mutex // because we temporarily replace os.Stdin/os.Stdout
@@ -90,6 +94,39 @@ func TestServeStdio_Initialize(t *testing.T) {
 	}
 }
 
+func TestServeStdio_InitializeReportsCLI(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bru is a shell script")
+	}
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "bru"), []byte("#!/bin/sh\necho 1.38.1\n"), 0o755); err != nil {
+		t.Fatalf("write fake bru: %v", err)
+	}
+	t.Setenv("PATH", bin)
+	s := NewServer()
+	s.RegisterCoreMethods()
+
+	out, err := runServeStdio(t, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`+"\n", s)
+	if err != nil {
+		t.Fatalf("ServeStdio returned error: %v", err)
+	}
+	var resp struct {
+		Result struct {
+			Capabilities struct {
+				Experimental struct {
+					Bruno *bruno.CLIInfo `json:"bruno"`
+				} `json:"experimental"`
+			} `json:"capabilities"`
+		} `json:"result"`
+	}
+	if e := json.Unmarshal([]byte(strings.TrimSpace(out)), &resp); e != nil {
+		t.Fatalf("output is not valid JSON: %v\noutput=%s", e, out)
+	}
+	if info := resp.Result.Capabilities.Experimental.Bruno; info == nil || !info.Available || info.Version != "1.38.1" {
+		t.Fatalf("expected the bru CLI info in the capabilities, got %s", out)
+	}
+}
+
 func TestServeStdio_Notification_NoResponse(t *testing.T) {
 	s := NewServer()
 	s.RegisterCoreMethods()
@@ -102,3 +139,74 @@ func TestServeStdio_Notification_NoResponse(t *testing.T) {
 		t.Fatalf("expected no output for notification, got: %q", out)
 	}
 }
+
+func decodeResponses(t *testing.T, out string) []Response {
+	t.Helper()
+	var resps []Response
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var r Response
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid response line %q: %v", line, err)
+		}
+		resps = append(resps, r)
+	}
+	return resps
+}
+
+func TestServeStdio_Handshake(t *testing.T) {
+	s := NewServer()
+	s.RegisterCoreMethods()
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"ping"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{"roots":{"listChanged":true}},"clientInfo":{"name":"test","version":"1.0"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":6,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+	}, "\n") + "\n"
+	out, err := runServeStdio(t, input, s)
+	if err != nil {
+		t.Fatalf("ServeStdio returned error: %v", err)
+	}
+	resps := decodeResponses(t, out)
+	if len(resps) != 6 {
+		t.Fatalf("expected 6 responses, got %d: %s", len(resps), out)
+	}
+
+	if resps[0].Error == nil || resps[0].Error.Code != CodeInvalidRequest {
+		t.Fatalf("expected tools/list before initialize to be rejected, got %+v", resps[0])
+	}
+	if resps[1].Error != nil {
+		t.Fatalf("expected ping before initialize to succeed, got %+v", resps[1].Error)
+	}
+	result := resps[2].Result.(map[string]any)
+	if result["protocolVersion"] != "2025-03-26" || result["instructions"] == "" {
+		t.Fatalf("unexpected initialize result: %+v", result)
+	}
+	if tools := result["capabilities"].(map[string]any)["tools"].(map[string]any); tools["listChanged"] != false {
+		t.Fatalf("unexpected tools capability: %+v", tools)
+	}
+	if resps[3].Error == nil {
+		t.Fatalf("expected tools/list before initialized notification to be rejected")
+	}
+	if resps[4].Error != nil || resps[4].Result.(map[string]any)["tools"] == nil {
+		t.Fatalf("expected tools/list after handshake, got %+v", resps[4])
+	}
+	if resps[5].Error == nil {
+		t.Fatalf("expected second initialize to be rejected")
+	}
+	if caps := s.ClientCapabilities(); caps.Roots == nil || !caps.Roots.ListChanged {
+		t.Fatalf("expected client capabilities to be kept, got %+v", caps)
+	}
+}
+
+func TestNegotiateProtocolVersion(t *testing.T) {
+	if v := negotiateProtocolVersion("2024-11-05"); v != "2024-11-05" {
+		t.Fatalf("expected supported version echoed, got %q", v)
+	}
+	if v := negotiateProtocolVersion("1999-01-01"); v != supportedProtocolVersions[0] {
+		t.Fatalf("expected latest version for unknown request, got %q", v)
+	}
+}
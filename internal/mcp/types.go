@@ -20,6 +20,13 @@ type Response struct {
 	Error   *RPCError `json:"error,omitempty"`
 }
 
+// Notification is a message from the server that expects no response.
+type Notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
 type RPCError struct {
 	Code    int            `json:"code"`
 	Message string         `json:"message"`
@@ -32,3 +39,16 @@ func (r Request) IsNotification() bool {
 	}
 	return string(r.ID) == "null"
 }
+
+// ToolResult is the result of tools/call. Failures of the tool itself are
+// reported here with IsError set, not as JSON-RPC errors.
+type ToolResult struct {
+	Content           []Content `json:"content"`
+	StructuredContent any       `json:"structuredContent,omitempty"`
+	IsError           bool      `json:"isError,omitempty"`
+}
+
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
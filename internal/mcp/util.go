@@ -5,8 +5,8 @@ import (
 	"encoding/json"
 )
 
-func writeResponse(w *bufio.Writer, resp Response) error {
-	b, err := json.Marshal(resp)
+func writeMessage(w *bufio.Writer, msg any) error {
+	b, err := json.Marshal(msg)
 
 	if err != nil {
 		return err
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+)
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Collection}} run report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin: 1em 0; width: 100%; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+.pass { color: #1a7f37; }
+.fail, .error { color: #cf222e; }
+code { font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>{{.Collection}}</h1>
+<p>Run at {{.Timestamp}}</p>
+{{range .Iterations}}
+{{if $.Multiple}}<h2>Iteration {{.Index}}</h2>{{end}}
+<p>Requests: {{.Summary.TotalRequests}} total, <span class="pass">{{.Summary.PassedRequests}} passed</span>, <span class="fail">{{.Summary.FailedRequests}} failed</span>, <span class="error">{{.Summary.ErrorRequests}} errored</span>.
+Assertions: {{.Summary.TotalAssertions}} total, <span class="pass">{{.Summary.PassedAssertions}} passed</span>, <span class="fail">{{.Summary.FailedAssertions}} failed</span>.</p>
+<table>
+<tr><th>Request</th><th>Status</th><th>Response</th><th>Time</th><th>Assertions</th></tr>
+{{range .Results}}
+<tr>
+<td><code>{{.Path}}</code>{{if .Method}}<br>{{.Method}} <code>{{.URL}}</code>{{end}}</td>
+<td class="{{.Status}}">{{.Status}}</td>
+<td>{{if .Error}}<span class="error">{{.Error}}</span>{{else}}{{.Code}} {{.CodeText}}{{end}}</td>
+<td>{{.DurationMs}} ms</td>
+<td>{{range .Assertions}}<div class="{{.Status}}"><code>{{.LHSExpr}} {{.RHSExpr}}</code>{{if .Error}}: {{.Error}}{{end}}</div>{{end}}</td>
+</tr>
+{{end}}
+</table>
+{{end}}
+</body>
+</html>
+`))
+
+type htmlResult struct {
+	Path       string
+	Method     string
+	URL        string
+	Status     string
+	Error      string
+	Code       int
+	CodeText   string
+	DurationMs int64
+	Assertions []bruno.AssertionResult
+}
+
+type htmlIteration struct {
+	Index   int
+	Summary bruno.RunSummary
+	Results []htmlResult
+}
+
+// HTML writes a self-contained page with a summary and a row per request.
+func HTML(w io.Writer, run *Run) error {
+	data := struct {
+		Collection string
+		Timestamp  string
+		Multiple   bool
+		Iterations []htmlIteration
+	}{
+		Collection: run.Collection,
+		Timestamp:  run.Timestamp.UTC().Format(time.RFC3339),
+		Multiple:   len(run.Iterations) > 1,
+	}
+	for i, it := range run.Iterations {
+		hi := htmlIteration{Index: i + 1, Summary: it.Summary}
+		for j := range it.Results {
+			r := &it.Results[j]
+			hr := htmlResult{Path: r.Path, Status: requestStatus(r), Error: r.Error}
+			if resp := r.Response; resp != nil {
+				hr.Method = resp.Request.Method
+				hr.URL = resp.Request.URL
+				hr.Code = resp.Status
+				hr.CodeText = resp.StatusText
+				hr.DurationMs = resp.DurationMs
+				hr.Assertions = resp.Assertions
+			}
+			hi.Results = append(hi.Results, hr)
+		}
+		data.Iterations = append(data.Iterations, hi)
+	}
+	return htmlTemplate.Execute(w, data)
+}
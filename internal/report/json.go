@@ -0,0 +1,102 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+)
+
+// JSON writes the run in the shape of `bru run --reporter-json`.
+func JSON(w io.Writer, run *Run) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(ToJSONReport(run))
+}
+
+// ToJSONReport converts a run to Bruno's JSON reporter document.
+func ToJSONReport(run *Run) bruno.JSONReport {
+	out := bruno.JSONReport{}
+	for i, it := range run.Iterations {
+		iter := bruno.JSONReportIteration{
+			IterationIndex: i,
+			Summary: bruno.JSONReportSummary{
+				TotalRequests:    it.Summary.TotalRequests,
+				PassedRequests:   it.Summary.PassedRequests,
+				FailedRequests:   it.Summary.FailedRequests,
+				ErrorRequests:    it.Summary.ErrorRequests,
+				TotalAssertions:  it.Summary.TotalAssertions,
+				PassedAssertions: it.Summary.PassedAssertions,
+				FailedAssertions: it.Summary.FailedAssertions,
+			},
+			Results: []bruno.JSONReportResult{},
+		}
+
+		for j := range it.Results {
+			r := &it.Results[j]
+			res := bruno.JSONReportResult{
+				Test:             bruno.JSONReportTest{Filename: r.Path},
+				Status:           requestStatus(r),
+				AssertionResults: []bruno.JSONReportAssertion{},
+				TestResults:      []bruno.JSONReportTestCase{},
+				Suitename:        strings.TrimSuffix(r.Path, ".bru"),
+				IterationIndex:   i,
+			}
+			if r.Error != "" {
+				msg := r.Error
+				res.Error = &msg
+			}
+			if resp := r.Response; resp != nil {
+				res.Request = bruno.JSONReportRequest{
+					Method:  resp.Request.Method,
+					URL:     resp.Request.URL,
+					Headers: flatHeaders(resp.Request.Headers),
+				}
+				if resp.Request.Body != "" {
+					res.Request.Data = jsonOrText(resp.Request.Body)
+				}
+				res.Response = bruno.JSONReportResponse{
+					Status:       resp.Status,
+					StatusText:   resp.StatusText,
+					Headers:      flatHeaders(resp.Headers),
+					Data:         jsonOrText(resp.Body),
+					ResponseTime: resp.DurationMs,
+				}
+				res.Runtime = float64(resp.DurationMs) / 1000
+				for _, a := range resp.Assertions {
+					res.AssertionResults = append(res.AssertionResults, bruno.JSONReportAssertion{
+						LHSExpr:    a.LHSExpr,
+						RHSExpr:    a.RHSExpr,
+						RHSOperand: a.RHSOperand,
+						Operator:   a.Operator,
+						Status:     a.Status,
+						Error:      a.Error,
+					})
+				}
+			}
+			iter.Results = append(iter.Results, res)
+		}
+		out = append(out, iter)
+	}
+	return out
+}
+
+func flatHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		out[strings.ToLower(k)] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// jsonOrText decodes a JSON body so that it nests in the report, keeping
+// anything else as text.
+func jsonOrText(s string) any {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err == nil {
+		return v
+	}
+	return s
+}
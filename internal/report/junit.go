@@ -0,0 +1,103 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+}
+
+func seconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
+
+// JUnit writes one testsuite per request and one testcase per assertion. A
+// request that could not be sent is a single errored testcase.
+func JUnit(w io.Writer, run *Run) error {
+	doc := junitSuites{Name: run.Collection}
+	var total int64
+	ts := run.Timestamp.UTC().Format(time.RFC3339)
+
+	for i, it := range run.Iterations {
+		for j := range it.Results {
+			r := &it.Results[j]
+			suite := junitSuite{Name: suiteName(run, i, r), Timestamp: ts, Time: "0.000"}
+			classname := r.Path
+			if r.Response != nil {
+				classname = r.Response.Request.URL
+				suite.Time = seconds(r.Response.DurationMs)
+				total += r.Response.DurationMs
+			}
+
+			if r.Error != "" {
+				suite.Cases = append(suite.Cases, junitCase{
+					Name:      suite.Name,
+					Classname: classname,
+					Time:      suite.Time,
+					Error:     &junitProblem{Type: "error", Message: r.Error},
+				})
+				suite.Errors++
+			} else {
+				for _, a := range r.Response.Assertions {
+					c := junitCase{Name: a.LHSExpr + " " + a.RHSExpr, Classname: classname, Time: suite.Time}
+					if !a.Passed() {
+						c.Failure = &junitProblem{Type: "failure", Message: a.Error}
+						suite.Failures++
+					}
+					suite.Cases = append(suite.Cases, c)
+				}
+			}
+			suite.Tests = len(suite.Cases)
+
+			doc.Tests += suite.Tests
+			doc.Failures += suite.Failures
+			doc.Errors += suite.Errors
+			doc.Suites = append(doc.Suites, suite)
+		}
+	}
+	doc.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
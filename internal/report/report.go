@@ -0,0 +1,243 @@
+// Package report writes run results as JUnit XML, Bruno's JSON reporter
+// format and a self-contained HTML page.
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+)
+
+var ErrInvalidPath = errors.New("invalid report path")
+
+// Run is what the writers report on: one or more iterations of a run.
+type Run struct {
+	Collection string
+	Iterations []*bruno.CollectionRunResult
+	Timestamp  time.Time
+}
+
+// FromRequest wraps a single request execution as a one-request run.
+func FromRequest(collection, path string, res *bruno.RunResult) *Run {
+	return fromResult(collection, bruno.RequestRunResult{Path: path, Name: strings.TrimSuffix(filepath.Base(path), ".bru"), Response: res})
+}
+
+// FromRequestError wraps a request that could not be sent as a one-request
+// run, the way a collection run reports it.
+func FromRequestError(collection, path string, err error) *Run {
+	return fromResult(collection, bruno.RequestRunResult{Path: path, Name: strings.TrimSuffix(filepath.Base(path), ".bru"), Error: err.Error()})
+}
+
+func fromResult(collection string, rr bruno.RequestRunResult) *Run {
+	run := &bruno.CollectionRunResult{Collection: collection, Results: []bruno.RequestRunResult{rr}}
+	if rr.Response != nil {
+		run.DurationMs = rr.Response.DurationMs
+	}
+	run.Summary = summarize(run.Results)
+	return &Run{Collection: collection, Iterations: []*bruno.CollectionRunResult{run}, Timestamp: time.Now()}
+}
+
+func FromCollection(res *bruno.CollectionRunResult) *Run {
+	return &Run{Collection: res.Collection, Iterations: []*bruno.CollectionRunResult{res}, Timestamp: time.Now()}
+}
+
+func FromIterations(res *bruno.IterationRunResult) *Run {
+	run := &Run{Collection: res.Collection, Timestamp: time.Now()}
+	for _, it := range res.Iterations {
+		run.Iterations = append(run.Iterations, it.Run)
+	}
+	return run
+}
+
+func summarize(results []bruno.RequestRunResult) bruno.RunSummary {
+	var s bruno.RunSummary
+	for i := range results {
+		s.Add(&results[i])
+	}
+	return s
+}
+
+// Targets names the files to write, relative to the workspace. Empty fields
+// are skipped.
+type Targets struct {
+	JUnit string `json:"junit,omitempty"`
+	JSON  string `json:"json,omitempty"`
+	HTML  string `json:"html,omitempty"`
+}
+
+func (t Targets) Empty() bool { return t.JUnit == "" && t.JSON == "" && t.HTML == "" }
+
+// Validate checks that every target stays inside workspaceDir, so that a bad
+// path can be rejected before the run starts.
+func (t Targets) Validate(workspaceDir string) error {
+	for _, rel := range []string{t.JUnit, t.JSON, t.HTML} {
+		if rel == "" {
+			continue
+		}
+		if _, err := resolve(workspaceDir, rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFiles renders run into each target inside workspaceDir and returns the
+// paths written, relative to the workspace. All paths are validated before
+// anything is written.
+func WriteFiles(workspaceDir string, t Targets, run *Run) ([]string, error) {
+	type target struct {
+		rel   string
+		path  string
+		write func(io.Writer, *Run) error
+	}
+	var targets []target
+	for _, c := range []struct {
+		rel   string
+		write func(io.Writer, *Run) error
+	}{{t.JUnit, JUnit}, {t.JSON, JSON}, {t.HTML, HTML}} {
+		if c.rel == "" {
+			continue
+		}
+		path, err := resolve(workspaceDir, c.rel)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target{c.rel, path, c.write})
+	}
+
+	written := []string{}
+	for _, t := range targets {
+		var buf bytes.Buffer
+		if err := t.write(&buf, run); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+			return nil, fmt.Errorf("mkdir report dir: %w", err)
+		}
+		if err := os.WriteFile(t.path, buf.Bytes(), 0o644); err != nil {
+			return nil, fmt.Errorf("write report: %w", err)
+		}
+		written = append(written, filepath.ToSlash(filepath.Clean(t.rel)))
+	}
+	return written, nil
+}
+
+func resolve(workspaceDir, rel string) (string, error) {
+	if filepath.IsAbs(rel) {
+		return "", fmt.Errorf("%w: must be relative to the workspace: %q", ErrInvalidPath, rel)
+	}
+	base := filepath.Clean(workspaceDir)
+	path := filepath.Join(base, rel)
+	r, err := filepath.Rel(base, path)
+	if err != nil || r == "." || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: escapes the workspace: %q", ErrInvalidPath, rel)
+	}
+	return path, nil
+}
+
+// suiteName names a request in reports: its path without .bru, prefixed with
+// the iteration when there are several.
+func suiteName(run *Run, iteration int, r *bruno.RequestRunResult) string {
+	name := strings.TrimSuffix(r.Path, ".bru")
+	if len(run.Iterations) > 1 {
+		name = fmt.Sprintf("iteration %d: %s", iteration+1, name)
+	}
+	return name
+}
+
+// requestStatus is Bruno's per-request status.
+func requestStatus(r *bruno.RequestRunResult) string {
+	switch {
+	case r.Error != "":
+		return "error"
+	case r.Passed():
+		return "pass"
+	default:
+		return "fail"
+	}
+}
+
+// FromCLIRun converts the report of a `bru run` back into run results.
+// Script test results have no counterpart and are left out.
+func FromCLIRun(res *bruno.CLIRunResult) *Run {
+	run := &Run{Collection: res.Collection, Timestamp: time.Now()}
+	for _, it := range res.Iterations {
+		cr := &bruno.CollectionRunResult{
+			Collection:  res.Collection,
+			Folder:      res.Folder,
+			Environment: res.Environment,
+			Results:     []bruno.RequestRunResult{},
+		}
+		for _, r := range it.Results {
+			rr := bruno.RequestRunResult{Path: r.Test.Filename, Name: strings.TrimSuffix(filepath.Base(r.Test.Filename), ".bru")}
+			if r.Error != nil {
+				rr.Error = *r.Error
+			} else {
+				rr.Response = cliResponse(&r)
+			}
+			cr.Results = append(cr.Results, rr)
+			cr.DurationMs += int64(r.Runtime * 1000)
+		}
+		cr.Summary = summarize(cr.Results)
+		run.Iterations = append(run.Iterations, cr)
+	}
+	return run
+}
+
+func cliResponse(r *bruno.JSONReportResult) *bruno.RunResult {
+	res := &bruno.RunResult{
+		Request: bruno.SentRequest{
+			Method:  r.Request.Method,
+			URL:     r.Request.URL,
+			Headers: httpHeaders(r.Request.Headers),
+			Body:    dataText(r.Request.Data),
+		},
+		Status:     r.Response.Status,
+		StatusText: r.Response.StatusText,
+		Headers:    httpHeaders(r.Response.Headers),
+		Body:       dataText(r.Response.Data),
+		DurationMs: r.Response.ResponseTime,
+	}
+	for _, a := range r.AssertionResults {
+		res.Assertions = append(res.Assertions, bruno.AssertionResult{
+			LHSExpr:    a.LHSExpr,
+			RHSExpr:    a.RHSExpr,
+			Operator:   a.Operator,
+			RHSOperand: a.RHSOperand,
+			Status:     a.Status,
+			Error:      a.Error,
+		})
+	}
+	return res
+}
+
+func httpHeaders(m map[string]string) http.Header {
+	h := http.Header{}
+	for k, v := range m {
+		h.Set(k, v)
+	}
+	return h
+}
+
+func dataText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
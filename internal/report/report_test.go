@@ -0,0 +1,155 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+)
+
+func sampleRun() *Run {
+	ok := &bruno.RunResult{
+		Request:    bruno.SentRequest{Method: "GET", URL: "http://example.test/users"},
+		Status:     200,
+		StatusText: "OK",
+		Headers:    http.Header{"Content-Type": {"application/json"}},
+		Body:       `{"id":1}`,
+		DurationMs: 12,
+		Assertions: []bruno.AssertionResult{
+			{LHSExpr: "res.status", RHSExpr: "eq 200", Operator: "eq", RHSOperand: "200", Status: bruno.AssertPass},
+			{LHSExpr: "res.body.name", RHSExpr: "eq <b>", Operator: "eq", RHSOperand: "<b>", Status: bruno.AssertFail, Error: "expected <b>"},
+		},
+	}
+	res := &bruno.CollectionRunResult{
+		Collection: "api",
+		Results: []bruno.RequestRunResult{
+			{Path: "users/list.bru", Name: "list", Response: ok},
+			{Path: "users/get.bru", Name: "get", Error: "connection refused"},
+		},
+		Summary: bruno.RunSummary{TotalRequests: 2, FailedRequests: 1, ErrorRequests: 1, TotalAssertions: 2, PassedAssertions: 1, FailedAssertions: 1},
+	}
+	return FromCollection(res)
+}
+
+func TestJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := JUnit(&buf, sampleRun()); err != nil {
+		t.Fatal(err)
+	}
+	var doc junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid xml: %v\n%s", err, buf.String())
+	}
+	if doc.Tests != 3 || doc.Failures != 1 || doc.Errors != 1 || len(doc.Suites) != 2 {
+		t.Fatalf("unexpected totals: %+v", doc)
+	}
+	if doc.Suites[0].Name != "users/list" || doc.Suites[0].Cases[1].Failure == nil || doc.Suites[0].Cases[1].Failure.Message != "expected <b>" {
+		t.Fatalf("unexpected first suite: %+v", doc.Suites[0])
+	}
+	if e := doc.Suites[1].Cases[0].Error; e == nil || e.Message != "connection refused" {
+		t.Fatalf("expected error case, got %+v", doc.Suites[1])
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := JSON(&buf, sampleRun()); err != nil {
+		t.Fatal(err)
+	}
+	var rep bruno.JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &rep); err != nil {
+		t.Fatal(err)
+	}
+	if len(rep) != 1 || len(rep[0].Results) != 2 || rep[0].Summary.ErrorRequests != 1 {
+		t.Fatalf("unexpected report: %s", buf.String())
+	}
+	first, second := rep[0].Results[0], rep[0].Results[1]
+	if first.Status != "fail" || first.Response.Status != 200 || first.Response.Headers["content-type"] != "application/json" {
+		t.Fatalf("unexpected first result: %+v", first)
+	}
+	if body, ok := first.Response.Data.(map[string]any); !ok || body["id"] != float64(1) {
+		t.Fatalf("expected decoded body, got %#v", first.Response.Data)
+	}
+	if second.Status != "error" || second.Error == nil || *second.Error != "connection refused" {
+		t.Fatalf("unexpected second result: %+v", second)
+	}
+}
+
+func TestHTMLEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := HTML(&buf, sampleRun()); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<b>") || !strings.Contains(out, "&lt;b&gt;") {
+		t.Fatalf("expected escaped assertion text, got:\n%s", out)
+	}
+	if !strings.Contains(out, "users/list.bru") || !strings.Contains(out, "connection refused") {
+		t.Fatalf("expected requests in page, got:\n%s", out)
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	ws := t.TempDir()
+	written, err := WriteFiles(ws, Targets{JUnit: "reports/junit.xml", HTML: "reports/run.html"}, sampleRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 2 || written[0] != "reports/junit.xml" || written[1] != "reports/run.html" {
+		t.Fatalf("unexpected paths: %v", written)
+	}
+	for _, p := range written {
+		if _, err := os.Stat(filepath.Join(ws, p)); err != nil {
+			t.Fatalf("expected %s: %v", p, err)
+		}
+	}
+
+	for _, bad := range []string{"../out.xml", "/tmp/out.xml", "."} {
+		if _, err := WriteFiles(ws, Targets{JSON: "ok.json", JUnit: bad}, sampleRun()); !errors.Is(err, ErrInvalidPath) {
+			t.Fatalf("expected ErrInvalidPath for %q, got %v", bad, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(ws, "ok.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing written when a path is invalid")
+	}
+}
+
+func TestFromRequest(t *testing.T) {
+	run := FromRequest("api", "ping.bru", &bruno.RunResult{Status: 200, Assertions: []bruno.AssertionResult{{Status: bruno.AssertPass}}})
+	s := run.Iterations[0].Summary
+	if s.TotalRequests != 1 || s.PassedRequests != 1 || s.PassedAssertions != 1 {
+		t.Fatalf("unexpected summary: %+v", s)
+	}
+}
+
+func TestFromCLIRun(t *testing.T) {
+	msg := "connect ECONNREFUSED"
+	run := FromCLIRun(&bruno.CLIRunResult{
+		Collection: "api",
+		Iterations: bruno.JSONReport{{Results: []bruno.JSONReportResult{
+			{
+				Test:             bruno.JSONReportTest{Filename: "users/list.bru"},
+				Response:         bruno.JSONReportResponse{Status: 200, Data: map[string]any{"id": 1.0}},
+				AssertionResults: []bruno.JSONReportAssertion{{LHSExpr: "res.status", RHSExpr: "eq 200", Status: "pass"}},
+			},
+			{Test: bruno.JSONReportTest{Filename: "users/get.bru"}, Error: &msg},
+		}}},
+	})
+	it := run.Iterations[0]
+	if it.Summary.PassedRequests != 1 || it.Summary.ErrorRequests != 1 {
+		t.Fatalf("unexpected summary: %+v", it.Summary)
+	}
+	if body := it.Results[0].Response.Body; body != `{"id":1}` {
+		t.Fatalf("expected JSON body, got %q", body)
+	}
+	if it.Results[1].Error != msg {
+		t.Fatalf("expected error, got %+v", it.Results[1])
+	}
+}
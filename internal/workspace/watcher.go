@@ -0,0 +1,151 @@
+package workspace
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mayank2930/bruno-mcp-server/internal/bruno"
+)
+
+// Kinds of change reported by the Watcher.
+const (
+	Created  = "created"
+	Modified = "modified"
+	Deleted  = "deleted"
+)
+
+// Change is one file created, modified or deleted in a workspace. Path is
+// slash-separated and relative to the workspace, e.g. "api/users/get.bru".
+type Change struct {
+	Workspace string
+	Path      string
+	Op        string
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+type snapshot struct {
+	root  string
+	files map[string]fileState
+}
+
+// Watcher polls the registered workspaces for changes to .bru files and
+// bruno.json. Polling keeps it free of platform-specific dependencies.
+type Watcher struct {
+	reg      *Registry
+	interval time.Duration
+
+	mu    sync.Mutex
+	snaps map[string]snapshot
+}
+
+func NewWatcher(reg *Registry, interval time.Duration) *Watcher {
+	return &Watcher{reg: reg, interval: interval, snaps: make(map[string]snapshot)}
+}
+
+// Run polls until ctx is done and hands every non-empty batch of changes to fn.
+func (w *Watcher) Run(ctx context.Context, fn func([]Change)) {
+	w.Poll()
+
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if changes := w.Poll(); len(changes) > 0 {
+				fn(changes)
+			}
+		}
+	}
+}
+
+// Poll scans every registered workspace once and returns what changed since
+// the previous scan. The first scan of a workspace only records its state.
+func (w *Watcher) Poll() []Change {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var changes []Change
+	seen := make(map[string]bool)
+	for _, ws := range w.reg.List() {
+		seen[ws.Name] = true
+		files := scanFiles(ws.Path)
+
+		prev, ok := w.snaps[ws.Name]
+		w.snaps[ws.Name] = snapshot{root: ws.Path, files: files}
+		if !ok || prev.root != ws.Path {
+			continue
+		}
+		changes = append(changes, diffFiles(ws.Name, prev.files, files)...)
+	}
+	for name := range w.snaps {
+		if !seen[name] {
+			delete(w.snaps, name)
+		}
+	}
+	return changes
+}
+
+func diffFiles(ws string, before, after map[string]fileState) []Change {
+	var changes []Change
+	for p, st := range after {
+		old, ok := before[p]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Workspace: ws, Path: p, Op: Created})
+		case old != st:
+			changes = append(changes, Change{Workspace: ws, Path: p, Op: Modified})
+		}
+	}
+	for p := range before {
+		if _, ok := after[p]; !ok {
+			changes = append(changes, Change{Workspace: ws, Path: p, Op: Deleted})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// scanFiles lists the .bru files and bruno.json files under root. Unreadable
+// entries are skipped; they show up once they can be read.
+func scanFiles(root string) map[string]fileState {
+	files := make(map[string]fileState)
+	_ = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() && p != root {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if p != root && bruno.IgnoredDir(d.Name()) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || (!strings.HasSuffix(d.Name(), ".bru") && d.Name() != "bruno.json") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil
+		}
+		files[filepath.ToSlash(rel)] = fileState{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	return files
+}